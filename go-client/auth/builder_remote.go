@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RemoteBuilderSignPath is the builder signing path served by NewBuilderSignerHandler
+const RemoteBuilderSignPath = "/sign"
+
+// maxBuilderClockSkew bounds how far a request timestamp may be from the signer's clock,
+// so signatures cannot be prepared for later use
+const maxBuilderClockSkew = 5 * time.Minute
+
+// BuilderSigner produces the POLY_BUILDER_* headers for a request. BuilderConfig signs
+// locally; RemoteBuilderSigner asks a service that holds the builder credentials.
+type BuilderSigner interface {
+	BuilderHeaders(method string, path string, body *string, timestamp int64) (*L2WithBuilderHeader, error)
+}
+
+var (
+	_ BuilderSigner = (*BuilderConfig)(nil)
+	_ BuilderSigner = (*RemoteBuilderSigner)(nil)
+)
+
+// RemoteBuilderSignerConfig configures a RemoteBuilderSigner
+type RemoteBuilderSignerConfig struct {
+	// Endpoint is the signing URL ("https://builder-signer.internal/sign") or a
+	// Unix socket ("unix:///run/polymarket/builder.sock"), which is sent to /sign
+	Endpoint string
+	// AuthToken is sent as a bearer token when set
+	AuthToken string
+	// Timeout for each signing request (default 10 seconds)
+	Timeout time.Duration
+}
+
+// RemoteBuilderSigner delegates builder signing to an external service so the
+// builder secret never enters trading processes
+type RemoteBuilderSigner struct {
+	remote *remoteClient
+	path   string
+}
+
+type remoteBuilderRequest struct {
+	Method    string  `json:"method"`
+	Path      string  `json:"path"`
+	Body      *string `json:"body,omitempty"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+type remoteBuilderResponse struct {
+	POLYBuilderAPIKey     string `json:"POLY_BUILDER_API_KEY"`
+	POLYBuilderTimestamp  string `json:"POLY_BUILDER_TIMESTAMP"`
+	POLYBuilderPassphrase string `json:"POLY_BUILDER_PASSPHRASE"`
+	POLYBuilderSignature  string `json:"POLY_BUILDER_SIGNATURE"`
+}
+
+// NewRemoteBuilderSigner creates a builder signer for a remote signing endpoint
+func NewRemoteBuilderSigner(config *RemoteBuilderSignerConfig) (*RemoteBuilderSigner, error) {
+	if config == nil || config.Endpoint == "" {
+		return nil, fmt.Errorf("remote builder signer endpoint is required")
+	}
+
+	signer := &RemoteBuilderSigner{
+		remote: newRemoteClient(config.Endpoint, config.AuthToken, config.Timeout),
+	}
+	if strings.HasPrefix(config.Endpoint, "unix://") {
+		signer.path = RemoteBuilderSignPath
+	}
+
+	return signer, nil
+}
+
+// BuilderHeaders sends the request to sign to the remote service
+func (s *RemoteBuilderSigner) BuilderHeaders(method string, path string, body *string, timestamp int64) (*L2WithBuilderHeader, error) {
+	var resp remoteBuilderResponse
+	req := remoteBuilderRequest{Method: method, Path: path, Body: body, Timestamp: timestamp}
+	if err := s.remote.call(http.MethodPost, s.path, req, &resp); err != nil {
+		return nil, fmt.Errorf("remote builder signing failed: %w", err)
+	}
+	if resp.POLYBuilderAPIKey == "" || resp.POLYBuilderSignature == "" {
+		return nil, fmt.Errorf("remote builder signer returned incomplete headers")
+	}
+
+	return &L2WithBuilderHeader{
+		POLYBuilderAPIKey:     resp.POLYBuilderAPIKey,
+		POLYBuilderTimestamp:  resp.POLYBuilderTimestamp,
+		POLYBuilderPassphrase: resp.POLYBuilderPassphrase,
+		POLYBuilderSignature:  resp.POLYBuilderSignature,
+	}, nil
+}
+
+// NewBuilderSignerHandler serves the remote builder signing protocol for local builder
+// credentials. It is meant to run in a separate, locked-down service. Without an
+// authToken, only requests over a Unix socket are served; see ListenSigner.
+func NewBuilderSignerHandler(config *BuilderConfig, authToken string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(RemoteBuilderSignPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeSignerError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		var req remoteBuilderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeSignerError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		if req.Method == "" || !strings.HasPrefix(req.Path, "/") {
+			writeSignerError(w, http.StatusBadRequest, "method and path are required")
+			return
+		}
+		skew := time.Since(time.Unix(req.Timestamp, 0))
+		if skew > maxBuilderClockSkew || skew < -maxBuilderClockSkew {
+			writeSignerError(w, http.StatusBadRequest, "timestamp out of range")
+			return
+		}
+
+		headers, err := config.BuilderHeaders(strings.ToUpper(req.Method), req.Path, req.Body, req.Timestamp)
+		if err != nil {
+			writeSignerError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeSignerJSON(w, remoteBuilderResponse{
+			POLYBuilderAPIKey:     headers.POLYBuilderAPIKey,
+			POLYBuilderTimestamp:  headers.POLYBuilderTimestamp,
+			POLYBuilderPassphrase: headers.POLYBuilderPassphrase,
+			POLYBuilderSignature:  headers.POLYBuilderSignature,
+		})
+	})
+
+	return requireAuthorization(authToken, mux)
+}
@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+)
+
+var testBuilder = &auth.BuilderConfig{
+	APIKey:     "builder-key",
+	Secret:     "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+	Passphrase: "builder-pass",
+}
+
+// The signature is py-clob-client's HMAC vector
+func TestBuilderHeaders(t *testing.T) {
+	body := `{"hash": "0x123"}`
+	headers, err := testBuilder.BuilderHeaders("test-sign", "/orders", &body, 1000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers.POLYBuilderAPIKey != "builder-key" || headers.POLYBuilderPassphrase != "builder-pass" ||
+		headers.POLYBuilderTimestamp != "1000000" || headers.POLYBuilderSignature != "ZwAdJKvoYRlEKDkNMwd5BuwNNtg93kNaR_oU2HrfVvc=" {
+		t.Errorf("headers = %+v", headers)
+	}
+
+	if _, err := (&auth.BuilderConfig{APIKey: "key"}).BuilderHeaders("GET", "/orders", nil, 1000000); err == nil {
+		t.Error("expected an error for an incomplete builder config")
+	}
+}
+
+func TestRemoteBuilderSigner(t *testing.T) {
+	srv := httptest.NewServer(auth.NewBuilderSignerHandler(testBuilder, "secret"))
+	defer srv.Close()
+
+	signer, err := auth.NewRemoteBuilderSigner(&auth.RemoteBuilderSignerConfig{
+		Endpoint:  srv.URL + auth.RemoteBuilderSignPath,
+		AuthToken: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"order":{}}`
+	now := time.Now().Unix()
+	remote, err := signer.BuilderHeaders("post", "/order", &body, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, _ := testBuilder.BuilderHeaders("POST", "/order", &body, now)
+	if *remote != *local {
+		t.Errorf("remote headers = %+v, want %+v", remote, local)
+	}
+
+	// Timestamps far from the signer's clock are refused
+	if _, err := signer.BuilderHeaders("GET", "/orders", nil, now-3600); err == nil || !strings.Contains(err.Error(), "timestamp") {
+		t.Errorf("stale timestamp err = %v", err)
+	}
+
+	for _, token := range []string{"", "wrong"} {
+		unauthorized, _ := auth.NewRemoteBuilderSigner(&auth.RemoteBuilderSignerConfig{
+			Endpoint:  srv.URL + auth.RemoteBuilderSignPath,
+			AuthToken: token,
+		})
+		if _, err := unauthorized.BuilderHeaders("GET", "/orders", nil, now); err == nil || !strings.Contains(err.Error(), "401") {
+			t.Errorf("token %q: err = %v, want a 401", token, err)
+		}
+	}
+
+	// Without a token the handler only serves Unix sockets
+	open := httptest.NewServer(auth.NewBuilderSignerHandler(testBuilder, ""))
+	defer open.Close()
+	tokenless, _ := auth.NewRemoteBuilderSigner(&auth.RemoteBuilderSignerConfig{Endpoint: open.URL + auth.RemoteBuilderSignPath})
+	if _, err := tokenless.BuilderHeaders("GET", "/orders", nil, now); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("tokenless TCP err = %v, want a 401", err)
+	}
+}
@@ -0,0 +1,301 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+// CredentialStore persists API key credentials so they can be reused across restarts.
+// Load returns nil, nil when no credentials are stored for the key.
+type CredentialStore interface {
+	Load(key string) (*types.ApiKeyCreds, error)
+	Save(key string, creds *types.ApiKeyCreds) error
+	Delete(key string) error
+}
+
+// CredentialKey identifies the credentials of a signer on a chain, e.g. "137:0xAbC..."
+func CredentialKey(chainID types.Chain, address common.Address) string {
+	return fmt.Sprintf("%d:%s", chainID, address.Hex())
+}
+
+// MemoryCredentialStore keeps credentials in memory for the lifetime of the process
+type MemoryCredentialStore struct {
+	mu    sync.RWMutex
+	creds map[string]types.ApiKeyCreds
+}
+
+// NewMemoryCredentialStore creates an empty in-memory credential store
+func NewMemoryCredentialStore() *MemoryCredentialStore {
+	return &MemoryCredentialStore{creds: make(map[string]types.ApiKeyCreds)}
+}
+
+// Load returns the stored credentials for key
+func (s *MemoryCredentialStore) Load(key string) (*types.ApiKeyCreds, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	creds, ok := s.creds[key]
+	if !ok {
+		return nil, nil
+	}
+	return &creds, nil
+}
+
+// Save stores credentials for key
+func (s *MemoryCredentialStore) Save(key string, creds *types.ApiKeyCreds) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.creds[key] = *creds
+	return nil
+}
+
+// Delete removes the credentials for key
+func (s *MemoryCredentialStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.creds, key)
+	return nil
+}
+
+// EnvCredentialStore reads credentials from environment variables
+// ({Prefix}API_KEY, {Prefix}API_SECRET, {Prefix}API_PASSPHRASE and the optional
+// {Prefix}API_NONCE of a rotated key).
+// It holds a single set of credentials regardless of the key and is read-only:
+// Save and Delete are no-ops.
+type EnvCredentialStore struct {
+	// Prefix defaults to "POLY_"
+	Prefix string
+}
+
+// Load returns the credentials from the environment, or nil if any variable is missing
+func (s *EnvCredentialStore) Load(key string) (*types.ApiKeyCreds, error) {
+	prefix := s.Prefix
+	if prefix == "" {
+		prefix = "POLY_"
+	}
+
+	creds := &types.ApiKeyCreds{
+		Key:        os.Getenv(prefix + "API_KEY"),
+		Secret:     os.Getenv(prefix + "API_SECRET"),
+		Passphrase: os.Getenv(prefix + "API_PASSPHRASE"),
+	}
+	if creds.Key == "" || creds.Secret == "" || creds.Passphrase == "" {
+		return nil, nil
+	}
+	if nonce := os.Getenv(prefix + "API_NONCE"); nonce != "" {
+		n, err := strconv.ParseUint(nonce, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sAPI_NONCE: %w", prefix, err)
+		}
+		creds.Nonce = n
+	}
+	return creds, nil
+}
+
+// Save is a no-op, environment variables are not written
+func (s *EnvCredentialStore) Save(key string, creds *types.ApiKeyCreds) error {
+	return nil
+}
+
+// Delete is a no-op, environment variables are not written
+func (s *EnvCredentialStore) Delete(key string) error {
+	return nil
+}
+
+// FileCredentialStore keeps credentials in a file encrypted with AES-256-GCM,
+// using a key derived from a passphrase with scrypt
+type FileCredentialStore struct {
+	path       string
+	passphrase string
+	mu         sync.Mutex
+}
+
+// credentialFile is the on-disk format of a FileCredentialStore
+type credentialFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+const (
+	credentialFileVersion = 1
+	credentialScryptN     = 1 << 15
+	credentialScryptR     = 8
+	credentialScryptP     = 1
+)
+
+// NewFileCredentialStore creates a store backed by an encrypted file; the file is created on first save
+func NewFileCredentialStore(path string, passphrase string) (*FileCredentialStore, error) {
+	if path == "" {
+		return nil, fmt.Errorf("credential file path is required")
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("credential file passphrase is required")
+	}
+	return &FileCredentialStore{path: path, passphrase: passphrase}, nil
+}
+
+// Load returns the stored credentials for key
+func (s *FileCredentialStore) Load(key string) (*types.ApiKeyCreds, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	creds, ok := all[key]
+	if !ok {
+		return nil, nil
+	}
+	return &creds, nil
+}
+
+// Save stores credentials for key
+func (s *FileCredentialStore) Save(key string, creds *types.ApiKeyCreds) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	all[key] = *creds
+	return s.write(all)
+}
+
+// Delete removes the credentials for key
+func (s *FileCredentialStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[key]; !ok {
+		return nil
+	}
+	delete(all, key)
+	return s.write(all)
+}
+
+func (s *FileCredentialStore) read() (map[string]types.ApiKeyCreds, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]types.ApiKeyCreds), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential file: %w", err)
+	}
+
+	var file credentialFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse credential file: %w", err)
+	}
+	if file.Version != credentialFileVersion {
+		return nil, fmt.Errorf("unsupported credential file version %d", file.Version)
+	}
+
+	gcm, err := s.cipher(file.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credential file (wrong passphrase?)")
+	}
+
+	all := make(map[string]types.ApiKeyCreds)
+	if err := json.Unmarshal(plaintext, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+	return all, nil
+}
+
+// write encrypts and atomically replaces the credential file
+func (s *FileCredentialStore) write(all map[string]types.ApiKeyCreds) error {
+	plaintext, err := json.Marshal(all)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	data, err := json.Marshal(credentialFile{
+		Version:    credentialFileVersion,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal credential file: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create credential directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create credential file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credential file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credential file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace credential file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileCredentialStore) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(s.passphrase), salt, credentialScryptN, credentialScryptR, credentialScryptP, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
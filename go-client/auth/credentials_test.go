@@ -0,0 +1,105 @@
+package auth_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestFileCredentialStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "creds", "store.json")
+	store, err := auth.NewFileCredentialStore(path, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := auth.CredentialKey(types.ChainPolygon, common.HexToAddress(testFunder))
+	if key != "137:"+testFunder {
+		t.Errorf("CredentialKey = %s", key)
+	}
+
+	if creds, err := store.Load(key); err != nil || creds != nil {
+		t.Fatalf("Load before any save = %v, %v", creds, err)
+	}
+
+	creds := &types.ApiKeyCreds{Key: "key", Secret: "c2VjcmV0", Passphrase: "pass"}
+	if err := store.Save(key, creds); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("80002:other", &types.ApiKeyCreds{Key: "other"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "c2VjcmV0") {
+		t.Error("the credential file contains the secret in plain text")
+	}
+	if runtime.GOOS != "windows" {
+		if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+			t.Errorf("credential file permissions = %o, want 600", info.Mode().Perm())
+		}
+	}
+
+	// A new store with the same passphrase reads what the first one wrote
+	reopened, _ := auth.NewFileCredentialStore(path, "hunter2")
+	loaded, err := reopened.Load(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded == nil || *loaded != *creds {
+		t.Errorf("loaded = %+v, want %+v", loaded, creds)
+	}
+
+	if err := reopened.Delete(key); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _ := store.Load(key); loaded != nil {
+		t.Errorf("Load after Delete = %+v", loaded)
+	}
+	if other, _ := store.Load("80002:other"); other == nil || other.Key != "other" {
+		t.Errorf("Delete dropped another key: %+v", other)
+	}
+
+	wrong, _ := auth.NewFileCredentialStore(path, "hunter3")
+	if _, err := wrong.Load(key); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("Load with the wrong passphrase err = %v", err)
+	}
+	if err := wrong.Save(key, creds); err == nil {
+		t.Error("Save with the wrong passphrase overwrote the file")
+	}
+
+	if _, err := auth.NewFileCredentialStore(path, ""); err == nil {
+		t.Error("expected an error for an empty passphrase")
+	}
+}
+
+func TestEnvCredentialStore(t *testing.T) {
+	t.Setenv("TEST_API_KEY", "key")
+	t.Setenv("TEST_API_SECRET", "c2VjcmV0")
+	t.Setenv("TEST_API_PASSPHRASE", "pass")
+	t.Setenv("TEST_API_NONCE", "1700000000000")
+
+	store := &auth.EnvCredentialStore{Prefix: "TEST_"}
+	creds, err := store.Load("any")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := types.ApiKeyCreds{Key: "key", Secret: "c2VjcmV0", Passphrase: "pass", Nonce: 1700000000000}
+	if creds == nil || *creds != want {
+		t.Errorf("creds = %+v, want %+v", creds, want)
+	}
+
+	t.Setenv("TEST_API_NONCE", "soon")
+	if _, err := store.Load("any"); err == nil {
+		t.Error("expected an error for an invalid nonce")
+	}
+}
@@ -0,0 +1,57 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// The ClobAuth vector is the one used by Polymarket's py-clob-client
+const (
+	clobAuthHash      = "0x8f442df8073c9cf2e36d7b20ca9d64d1a9352e982cc82a293b7de2df57920610"
+	clobAuthSignature = "0xf62319a987514da40e57e2f4d7529f7bac38f0355bd88bb5adbb3768d80de6c1682518e0af677d5260366425f4361e7b70c25ae232aff0ab2331e2b164a1aedc1b"
+)
+
+func TestClobAuthTypedDataHash(t *testing.T) {
+	wallet := testWallet(t)
+
+	hash, err := auth.HashTypedData(auth.ClobAuthTypedData(wallet.GetAddressHex(), int64(types.ChainAmoy), 10000000, 23))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash.Hex() != clobAuthHash {
+		t.Errorf("hash = %s, want %s", hash.Hex(), clobAuthHash)
+	}
+
+	signature, err := auth.BuildClobEip712Signature(wallet.Signer(), int64(types.ChainAmoy), 10000000, 23)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signature != clobAuthSignature {
+		t.Errorf("signature = %s, want %s", signature, clobAuthSignature)
+	}
+}
+
+func TestCreateL1Headers(t *testing.T) {
+	wallet := testWallet(t)
+	nonce := uint64(23)
+	timestamp := int64(10000000)
+
+	headers, err := auth.CreateL1Headers(wallet.Signer(), types.ChainAmoy, &nonce, &timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers.POLYAddress != wallet.GetAddressHex() || headers.POLYSignature != clobAuthSignature ||
+		headers.POLYTimestamp != "10000000" || headers.POLYNonce != "23" {
+		t.Errorf("headers = %+v", headers)
+	}
+
+	ok, err := auth.VerifyEIP712Signature(wallet.GetAddressHex(), clobAuthSignature, timestamp, nonce, types.ChainAmoy)
+	if err != nil || !ok {
+		t.Errorf("VerifyEIP712Signature = %v, %v", ok, err)
+	}
+	if ok, _ := auth.VerifyEIP712Signature(testFunder, clobAuthSignature, timestamp, nonce, types.ChainAmoy); ok {
+		t.Error("signature verified for another address")
+	}
+}
@@ -1,18 +1,17 @@
 package auth
 
 import (
-	"crypto/ecdsa"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/HuakunShen/polymarket-kit/go-client/types"
 )
 
-// CreateL1Headers creates Level 1 authentication headers for API key creation
-func CreateL1Headers(privateKey *ecdsa.PrivateKey, chainID types.Chain, nonce *uint64, timestamp *int64) (*types.L1PolyHeader, error) {
+// CreateL1Headers creates Level 1 authentication headers for API key creation.
+// POLY_ADDRESS is always the signing EOA, even when orders are funded by a proxy wallet or Safe.
+func CreateL1Headers(signer Signer, chainID types.Chain, nonce *uint64, timestamp *int64) (*types.L1PolyHeader, error) {
 	// Default timestamp to current time if not provided
 	ts := time.Now().Unix()
 	if timestamp != nil {
@@ -26,13 +25,13 @@ func CreateL1Headers(privateKey *ecdsa.PrivateKey, chainID types.Chain, nonce *u
 	}
 
 	// Build EIP712 signature
-	sig, err := BuildClobEip712Signature(privateKey, int64(chainID), ts, n)
+	sig, err := BuildClobEip712Signature(signer, int64(chainID), ts, n)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build EIP712 signature: %w", err)
 	}
 
-	// Get address from private key
-	address := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	// Get address from signer
+	address := signer.Address().Hex()
 
 	headers := &types.L1PolyHeader{
 		POLYAddress:   address,
@@ -44,16 +43,17 @@ func CreateL1Headers(privateKey *ecdsa.PrivateKey, chainID types.Chain, nonce *u
 	return headers, nil
 }
 
-// CreateL2Headers creates Level 2 authentication headers for API operations
-func CreateL2Headers(privateKey *ecdsa.PrivateKey, creds *types.ApiKeyCreds, l2HeaderArgs *types.L2HeaderArgs, timestamp *int64) (*types.L2PolyHeader, error) {
+// CreateL2Headers creates Level 2 authentication headers for API operations.
+// Like L1 headers, POLY_ADDRESS is the signing EOA that owns the API key, not the funder.
+func CreateL2Headers(signer Signer, creds *types.ApiKeyCreds, l2HeaderArgs *types.L2HeaderArgs, timestamp *int64) (*types.L2PolyHeader, error) {
 	// Default timestamp to current time if not provided
 	ts := time.Now().Unix()
 	if timestamp != nil {
 		ts = *timestamp
 	}
 
-	// Get address from private key
-	address := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	// Get address from signer
+	address := signer.Address().Hex()
 
 	// Build HMAC signature
 	var body *string
@@ -97,23 +97,21 @@ func (bc *BuilderConfig) IsValid() bool {
 
 // GenerateBuilderHeaders generates builder headers
 func (bc *BuilderConfig) GenerateBuilderHeaders(method string, path string, body *string) (*L2WithBuilderHeader, error) {
+	return bc.BuilderHeaders(method, path, body, time.Now().Unix())
+}
+
+// BuilderHeaders signs a request with the builder credentials at the given timestamp
+func (bc *BuilderConfig) BuilderHeaders(method string, path string, body *string, timestamp int64) (*L2WithBuilderHeader, error) {
 	if !bc.IsValid() {
 		return nil, fmt.Errorf("invalid builder config")
 	}
 
-	ts := time.Now().Unix()
-
-	var bodyStr *string
-	if body != nil {
-		bodyStr = body
-	}
-
 	// Generate builder signature using same HMAC method
-	sig := BuildPolyHmacSignature(bc.Secret, ts, method, path, bodyStr)
+	sig := BuildPolyHmacSignature(bc.Secret, timestamp, method, path, body)
 
 	builderHeaders := &L2WithBuilderHeader{
 		POLYBuilderAPIKey:     bc.APIKey,
-		POLYBuilderTimestamp:  strconv.FormatInt(ts, 10),
+		POLYBuilderTimestamp:  strconv.FormatInt(timestamp, 10),
 		POLYBuilderPassphrase: bc.Passphrase,
 		POLYBuilderSignature:  sig,
 	}
@@ -142,34 +140,9 @@ func VerifyEIP712Signature(address string, signature string, timestamp int64, no
 	}
 
 	// Create the typed data hash
-	domain := EIP712Domain{
-		Name:    "ClobAuthDomain",
-		Version: "1",
-		ChainID: int64(chainID),
-	}
-
-	message := ClobAuthData{
-		Address:   address,
-		Timestamp: fmt.Sprintf("%d", timestamp),
-		Nonce:     nonce,
-		Message:   MSG_TO_SIGN,
-	}
-
-	typedData := TypedData{
-		Types: map[string][]EIP712Type{
-			"ClobAuth": {
-				{Name: "address", Type: "address"},
-				{Name: "timestamp", Type: "string"},
-				{Name: "nonce", Type: "uint256"},
-				{Name: "message", Type: "string"},
-			},
-		},
-		PrimaryType: "ClobAuth",
-		Domain:      domain,
-		Message:     message,
-	}
+	typedData := ClobAuthTypedData(address, int64(chainID), timestamp, nonce)
 
-	hash, err := getTypedDataHash(typedData)
+	hash, err := HashTypedData(typedData)
 	if err != nil {
 		return false, fmt.Errorf("failed to get typed data hash: %w", err)
 	}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/google/uuid"
+)
+
+// KeystoreConfig points to an encrypted Ethereum V3 keystore file
+type KeystoreConfig struct {
+	Path       string
+	Passphrase string
+}
+
+// NewWalletFromKeystore creates a wallet from Ethereum V3 keystore JSON
+func NewWalletFromKeystore(keyJSON []byte, passphrase string) (*Wallet, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+
+	return NewWalletFromPrivateKey(key.PrivateKey), nil
+}
+
+// NewWalletFromKeystoreFile creates a wallet from an Ethereum V3 keystore file
+func NewWalletFromKeystoreFile(path string, passphrase string) (*Wallet, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	return NewWalletFromKeystore(keyJSON, passphrase)
+}
+
+// Load unlocks the keystore described by the config
+func (kc *KeystoreConfig) Load() (*Wallet, error) {
+	if kc == nil || kc.Path == "" {
+		return nil, fmt.Errorf("keystore path is required")
+	}
+	return NewWalletFromKeystoreFile(kc.Path, kc.Passphrase)
+}
+
+// ExportKeystore encrypts the wallet key as Ethereum V3 keystore JSON (standard scrypt parameters)
+func (w *Wallet) ExportKeystore(passphrase string) ([]byte, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key id: %w", err)
+	}
+
+	key := &keystore.Key{
+		Id:         id,
+		Address:    w.address,
+		PrivateKey: w.privateKey,
+	}
+
+	keyJSON, err := keystore.EncryptKey(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt key: %w", err)
+	}
+
+	return keyJSON, nil
+}
+
+// SaveKeystore writes the encrypted wallet key to path with owner-only permissions
+func (w *Wallet) SaveKeystore(path string, passphrase string) error {
+	keyJSON, err := w.ExportKeystore(passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, keyJSON, 0600); err != nil {
+		return fmt.Errorf("failed to write keystore file: %w", err)
+	}
+
+	return nil
+}
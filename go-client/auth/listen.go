@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strings"
+)
+
+// ListenSigner opens the listener for a signing service: "unix:///path/to.sock" or
+// "host:port". The Unix socket is created accessible to the owning user only. TCP
+// requires an auth token, since anyone who can reach the port could otherwise sign.
+func ListenSigner(address string, authToken string) (net.Listener, error) {
+	if !strings.HasPrefix(address, "unix://") {
+		if authToken == "" {
+			return nil, errors.New("an auth token is required to listen on TCP; use a unix:// socket otherwise")
+		}
+		return net.Listen("tcp", address)
+	}
+
+	socketPath := strings.TrimPrefix(address, "unix://")
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return listenPrivateUnix(socketPath)
+}
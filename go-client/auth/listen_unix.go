@@ -0,0 +1,18 @@
+//go:build !windows
+
+package auth
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenPrivateUnix creates the socket with mode 0600 from the start, so there is no
+// window in which others can connect. The umask is process-wide; call this during
+// startup, before other goroutines create files.
+func listenPrivateUnix(socketPath string) (net.Listener, error) {
+	previous := syscall.Umask(0o077)
+	defer syscall.Umask(previous)
+
+	return net.Listen("unix", socketPath)
+}
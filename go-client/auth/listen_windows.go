@@ -0,0 +1,10 @@
+//go:build windows
+
+package auth
+
+import "net"
+
+// listenPrivateUnix creates the socket; Windows does not apply Unix file modes to it
+func listenPrivateUnix(socketPath string) (net.Listener, error) {
+	return net.Listen("unix", socketPath)
+}
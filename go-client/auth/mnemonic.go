@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultDerivationPath is the standard Ethereum BIP-44 path of the first account
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+// MnemonicConfig describes a BIP-39 mnemonic and the account to derive from it
+type MnemonicConfig struct {
+	Mnemonic string
+	// DerivationPath defaults to DefaultDerivationPath
+	DerivationPath string
+	// Passphrase is the optional BIP-39 passphrase ("25th word")
+	Passphrase string
+}
+
+// NewWalletFromMnemonic derives a wallet from a BIP-39 mnemonic and BIP-32 derivation path
+func NewWalletFromMnemonic(mnemonic string, derivationPath string) (*Wallet, error) {
+	return (&MnemonicConfig{Mnemonic: mnemonic, DerivationPath: derivationPath}).Load()
+}
+
+// NewMnemonic generates a new 24 word BIP-39 mnemonic
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate entropy: %w", err)
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// Load derives the wallet described by the config
+func (mc *MnemonicConfig) Load() (*Wallet, error) {
+	if mc == nil || mc.Mnemonic == "" {
+		return nil, fmt.Errorf("mnemonic is required")
+	}
+
+	seed, err := bip39.NewSeedWithErrorChecking(mc.Mnemonic, mc.Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	path := mc.DerivationPath
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path: %w", err)
+	}
+
+	privateKey, err := deriveKey(seed, derivationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWalletFromPrivateKey(privateKey), nil
+}
+
+// deriveKey walks a BIP-32 path from the master key of a seed
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key, chainCode := sum[:32], sum[32:]
+	curveOrder := crypto.S256().Params().N
+
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			// Hardened child: 0x00 || ser256(k) || ser32(i)
+			data = append([]byte{0}, key...)
+		} else {
+			// Normal child: serP(point(k)) || ser32(i)
+			parent, err := crypto.ToECDSA(key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid parent key: %w", err)
+			}
+			data = crypto.CompressPubkey(&parent.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(curveOrder) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+
+		child := tweak.Add(tweak, new(big.Int).SetBytes(key))
+		child.Mod(child, curveOrder)
+		if child.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+
+		key = child.FillBytes(make([]byte, 32))
+		chainCode = sum[32:]
+	}
+
+	return crypto.ToECDSA(key)
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestDeriveKey checks the private keys of BIP-32 test vectors 1 and 2
+func TestDeriveKey(t *testing.T) {
+	tests := []struct {
+		seed string
+		path string
+		key  string
+	}{
+		{"000102030405060708090a0b0c0d0e0f", "m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"000102030405060708090a0b0c0d0e0f", "m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"000102030405060708090a0b0c0d0e0f", "m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"000102030405060708090a0b0c0d0e0f", "m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"000102030405060708090a0b0c0d0e0f", "m/0'/1/2'/2", "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"000102030405060708090a0b0c0d0e0f", "m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+		{
+			"fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+			"m", "4b03d6fc340455b363f51020ad3ecca4f0850280cf436c70c727923f6db46c3e",
+		},
+		{
+			"fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+			"m/0", "abe74a98f6c7eabee0428f53798f0ab8aa1bd37873999041703c742f15ac7e1e",
+		},
+		{
+			"fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+			"m/0/2147483647'/1/2147483646'/2", "bb7d39bdb83ecf58f2fd82b6d918341cbef428661ef01ab97c28a4842125ac23",
+		},
+	}
+
+	for _, tt := range tests {
+		seed, _ := hex.DecodeString(tt.seed)
+		var path accounts.DerivationPath
+		if tt.path != "m" {
+			var err error
+			if path, err = accounts.ParseDerivationPath(tt.path); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		key, err := deriveKey(seed, path)
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+			continue
+		}
+		if got := hex.EncodeToString(crypto.FromECDSA(key)); got != tt.key {
+			t.Errorf("%s: key = %s, want %s", tt.path, got, tt.key)
+		}
+	}
+}
+
+// TestNewWalletFromMnemonic uses the default Hardhat/Anvil development mnemonic
+func TestNewWalletFromMnemonic(t *testing.T) {
+	const mnemonic = "test test test test test test test test test test test junk"
+
+	tests := []struct {
+		path    string
+		address string
+	}{
+		{"", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{"m/44'/60'/0'/0/1", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+	}
+	for _, tt := range tests {
+		wallet, err := NewWalletFromMnemonic(mnemonic, tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wallet.GetAddressHex() != tt.address {
+			t.Errorf("path %q: address = %s, want %s", tt.path, wallet.GetAddressHex(), tt.address)
+		}
+	}
+
+	if _, err := NewWalletFromMnemonic("test test test test test test test test test test test test", ""); err == nil {
+		t.Error("expected a checksum error")
+	}
+	withPassphrase, err := (&MnemonicConfig{Mnemonic: mnemonic, Passphrase: "TREZOR"}).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withPassphrase.GetAddressHex() == tests[0].address {
+		t.Error("the BIP-39 passphrase did not change the derived account")
+	}
+}
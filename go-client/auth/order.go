@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ORDER_DOMAIN_NAME is the EIP712 domain name of the CTF exchange
+	ORDER_DOMAIN_NAME = "Polymarket CTF Exchange"
+	// ORDER_DOMAIN_VERSION is the EIP712 domain version of the CTF exchange
+	ORDER_DOMAIN_VERSION = "1"
+)
+
+// OrderTypedData builds the EIP712 typed data of an order for the given exchange contract
+func OrderTypedData(order *types.SignedOrder, chainID int64, exchangeAddress string) (*TypedData, error) {
+	if order.MakerAmount == nil || order.TakerAmount == nil {
+		return nil, fmt.Errorf("maker and taker amounts are required")
+	}
+
+	var side string
+	switch order.Side {
+	case types.SideBuy:
+		side = "0"
+	case types.SideSell:
+		side = "1"
+	default:
+		return nil, fmt.Errorf("invalid side: %s", order.Side)
+	}
+
+	return &TypedData{
+		Types: map[string][]EIP712Type{
+			"Order": {
+				{Name: "salt", Type: "uint256"},
+				{Name: "maker", Type: "address"},
+				{Name: "signer", Type: "address"},
+				{Name: "taker", Type: "address"},
+				{Name: "tokenId", Type: "uint256"},
+				{Name: "makerAmount", Type: "uint256"},
+				{Name: "takerAmount", Type: "uint256"},
+				{Name: "expiration", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "feeRateBps", Type: "uint256"},
+				{Name: "side", Type: "uint8"},
+				{Name: "signatureType", Type: "uint8"},
+			},
+		},
+		PrimaryType: "Order",
+		Domain: EIP712Domain{
+			Name:              ORDER_DOMAIN_NAME,
+			Version:           ORDER_DOMAIN_VERSION,
+			ChainID:           chainID,
+			VerifyingContract: exchangeAddress,
+		},
+		Message: map[string]interface{}{
+			"salt":          order.Salt,
+			"maker":         order.Maker,
+			"signer":        order.Signer,
+			"taker":         order.Taker,
+			"tokenId":       order.TokenID,
+			"makerAmount":   order.MakerAmount.String(),
+			"takerAmount":   order.TakerAmount.String(),
+			"expiration":    order.Expiration,
+			"nonce":         order.Nonce,
+			"feeRateBps":    order.FeeRateBps,
+			"side":          side,
+			"signatureType": fmt.Sprintf("%d", order.SignatureType),
+		},
+	}, nil
+}
+
+// BuildOrderHash computes the EIP712 hash of an order for the given exchange contract
+func BuildOrderHash(order *types.SignedOrder, chainID int64, exchangeAddress string) (common.Hash, error) {
+	typedData, err := OrderTypedData(order, chainID, exchangeAddress)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return HashTypedData(typedData)
+}
+
+// BuildOrderSignature signs an order for the given exchange contract
+func BuildOrderSignature(signer Signer, order *types.SignedOrder, chainID int64, exchangeAddress string) (string, error) {
+	typedData, err := OrderTypedData(order, chainID, exchangeAddress)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := signer.SignTypedData(typedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign order: %w", err)
+	}
+
+	return hexutil.Encode(signature), nil
+}
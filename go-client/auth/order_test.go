@@ -0,0 +1,132 @@
+package auth_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// testPrivateKey is the first Hardhat development account, 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+const (
+	amoyExchange        = "0xdFE02Eb6733538f8Ea35D585af8DE5958AD99E40"
+	amoyNegRiskExchange = "0xC5d563A36AE78145C45a50134d48A1215220f80a"
+	testFunder          = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+func testWallet(t *testing.T) *auth.Wallet {
+	t.Helper()
+	wallet, err := auth.NewWalletFromHex(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wallet
+}
+
+func testOrder(maker string, signer string, signatureType types.SignatureType) *types.SignedOrder {
+	return &types.SignedOrder{
+		Salt:          "479249096354",
+		Maker:         maker,
+		Signer:        signer,
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenID:       "1234",
+		MakerAmount:   big.NewInt(100000000),
+		TakerAmount:   big.NewInt(50000000),
+		Expiration:    "0",
+		Nonce:         "0",
+		FeeRateBps:    "100",
+		Side:          types.SideBuy,
+		SignatureType: signatureType,
+	}
+}
+
+// The EOA vectors are the ones used by Polymarket's python-order-utils; the proxy and
+// Safe vectors sign for a funder that is not the signer.
+func TestOrderHashAndSignature(t *testing.T) {
+	wallet := testWallet(t)
+	signer := wallet.GetAddressHex()
+
+	tests := []struct {
+		name      string
+		order     *types.SignedOrder
+		exchange  string
+		hash      string
+		signature string
+	}{
+		{
+			name:      "eoa",
+			order:     testOrder(signer, signer, types.SignatureTypeEIP712),
+			exchange:  amoyExchange,
+			hash:      "0x02ca1d1aa31103804173ad1acd70066cb6c1258a4be6dada055111f9a7ea4e55",
+			signature: "0x302cd9abd0b5fcaa202a344437ec0b6660da984e24ae9ad915a592a90facf5a51bb8a873cd8d270f070217fea1986531d5eec66f1162a81f66e026db653bf7ce1c",
+		},
+		{
+			name:      "eoa neg risk",
+			order:     testOrder(signer, signer, types.SignatureTypeEIP712),
+			exchange:  amoyNegRiskExchange,
+			hash:      "0xf15790d3edc4b5aed427b0b543a9206fcf4b1a13dfed016d33bfb313076263b8",
+			signature: "0x1b3646ef347e5bd144c65bd3357ba19c12c12abaeedae733cf8579bc51a2752c0454c3bc6b236957e393637982c769b8dc0706c0f5c399983d933850afd1cbcd1c",
+		},
+		{
+			name:      "poly proxy",
+			order:     testOrder(testFunder, signer, types.SignatureTypePolyProxy),
+			exchange:  amoyExchange,
+			hash:      "0xa0b755c0cf1717b96f374e7a7af7b1bb82c0507557f036084a489da600ccb639",
+			signature: "0x25b9516b60e376029bd1546d1cd70e7ac189689d823260b7c525331d1fe8a63019978bb98e4129a25642a5c00ad4b1a8363630385a4ca20aa26ada982accf4e21b",
+		},
+		{
+			name:      "gnosis safe",
+			order:     testOrder(testFunder, signer, types.SignatureTypePolyGnosisSafe),
+			exchange:  amoyExchange,
+			hash:      "0x07488a507aa66be68721f562b5de89dc16d58d3515e2065d932668575cf9761a",
+			signature: "0x1bb2f9a1b263eb2ca0dcb71050be6877533f98987a43aec66f607daded7770cc7e0da329f5e589ca96cc2caaccb7ccde5cdbe08dc7f8eb7ffec844ebaf2475981b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := auth.BuildOrderHash(tt.order, int64(types.ChainAmoy), tt.exchange)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hash.Hex() != tt.hash {
+				t.Errorf("hash = %s, want %s", hash.Hex(), tt.hash)
+			}
+
+			signature, err := auth.BuildOrderSignature(wallet.Signer(), tt.order, int64(types.ChainAmoy), tt.exchange)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signature != tt.signature {
+				t.Errorf("signature = %s, want %s", signature, tt.signature)
+			}
+
+			// The signature always recovers to the signing EOA, never the funder
+			recovered, err := auth.RecoverAddress(hash, signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if recovered.Hex() != signer {
+				t.Errorf("recovered %s, want %s", recovered.Hex(), signer)
+			}
+		})
+	}
+}
+
+func TestOrderTypedDataRejectsInvalidOrders(t *testing.T) {
+	order := testOrder(testFunder, testFunder, types.SignatureTypeEIP712)
+	order.Side = "HOLD"
+	if _, err := auth.BuildOrderHash(order, int64(types.ChainAmoy), amoyExchange); err == nil || !strings.Contains(err.Error(), "side") {
+		t.Errorf("invalid side err = %v", err)
+	}
+
+	order = testOrder(testFunder, testFunder, types.SignatureTypeEIP712)
+	order.MakerAmount = nil
+	if _, err := auth.BuildOrderHash(order, int64(types.ChainAmoy), amoyExchange); err == nil {
+		t.Error("expected an error for a missing maker amount")
+	}
+}
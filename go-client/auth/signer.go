@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer abstracts the key used to sign auth headers and orders.
+// Signatures are 65 bytes [R || S || V] with V in {27, 28}.
+type Signer interface {
+	// Address returns the address of the signing key
+	Address() common.Address
+	// SignHash signs a 32 byte digest
+	SignHash(hash common.Hash) ([]byte, error)
+	// SignTypedData signs EIP-712 typed data
+	SignTypedData(typedData *TypedData) ([]byte, error)
+}
+
+// PrivateKeySigner signs with an in-memory private key
+type PrivateKeySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewPrivateKeySigner creates a signer from an in-memory private key
+func NewPrivateKeySigner(privateKey *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+}
+
+// Address returns the signer address
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash signs a digest with the private key
+func (s *PrivateKeySigner) SignHash(hash common.Hash) ([]byte, error) {
+	signature, err := crypto.Sign(hash.Bytes(), s.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash: %w", err)
+	}
+
+	// Adjust v value from 0/1 to 27/28 (Ethereum standard)
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+
+	return signature, nil
+}
+
+// SignTypedData hashes and signs EIP-712 typed data
+func (s *PrivateKeySigner) SignTypedData(typedData *TypedData) ([]byte, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get typed data hash: %w", err)
+	}
+	return s.SignHash(hash)
+}
+
+// NewKeystoreSigner creates a signer from an encrypted Ethereum V3 keystore file
+func NewKeystoreSigner(path string, passphrase string) (*PrivateKeySigner, error) {
+	wallet, err := NewWalletFromKeystoreFile(path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrivateKeySigner(wallet.privateKey), nil
+}
+
+// Signer returns a Signer backed by the wallet's private key
+func (w *Wallet) Signer() Signer {
+	return NewPrivateKeySigner(w.privateKey)
+}
+
+// normalizeSignature validates a signature and adjusts V to 27/28
+func normalizeSignature(signature []byte) ([]byte, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("signature must be 65 bytes long, got %d", len(signature))
+	}
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+	return signature, nil
+}
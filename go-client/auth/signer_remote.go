@@ -0,0 +1,305 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Remote signer protocol paths
+const (
+	RemoteSignerAddressPath   = "/address"
+	RemoteSignerHashPath      = "/sign/hash"
+	RemoteSignerTypedDataPath = "/sign/typed-data"
+)
+
+// RemoteSignerConfig configures a RemoteSigner
+type RemoteSignerConfig struct {
+	// Endpoint is either an HTTP URL ("http://127.0.0.1:8550") or a
+	// Unix socket ("unix:///run/polymarket/signer.sock")
+	Endpoint string
+	// AuthToken is sent as a bearer token when set
+	AuthToken string
+	// Timeout for each signing request (default 10 seconds)
+	Timeout time.Duration
+}
+
+// RemoteSigner delegates signing to an external process so the key never
+// enters application memory
+type RemoteSigner struct {
+	remote  *remoteClient
+	address common.Address
+}
+
+// remoteClient calls a JSON signing service over HTTP or a Unix socket
+type remoteClient struct {
+	baseURL    string
+	authToken  string
+	httpClient *http.Client
+}
+
+type remoteAddressResponse struct {
+	Address string `json:"address"`
+}
+
+type remoteHashRequest struct {
+	Hash string `json:"hash"`
+}
+
+type remoteSignatureResponse struct {
+	Signature string `json:"signature"`
+}
+
+type remoteErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewRemoteSigner connects to a remote signer and fetches its address
+func NewRemoteSigner(config *RemoteSignerConfig) (*RemoteSigner, error) {
+	if config == nil || config.Endpoint == "" {
+		return nil, fmt.Errorf("remote signer endpoint is required")
+	}
+
+	signer := &RemoteSigner{
+		remote: newRemoteClient(config.Endpoint, config.AuthToken, config.Timeout),
+	}
+
+	var resp remoteAddressResponse
+	if err := signer.remote.call(http.MethodGet, RemoteSignerAddressPath, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get remote signer address: %w", err)
+	}
+	if !common.IsHexAddress(resp.Address) {
+		return nil, fmt.Errorf("remote signer returned invalid address: %q", resp.Address)
+	}
+	signer.address = common.HexToAddress(resp.Address)
+
+	return signer, nil
+}
+
+// Address returns the address reported by the remote signer
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash asks the remote signer to sign a digest
+func (s *RemoteSigner) SignHash(hash common.Hash) ([]byte, error) {
+	var resp remoteSignatureResponse
+	if err := s.remote.call(http.MethodPost, RemoteSignerHashPath, remoteHashRequest{Hash: hash.Hex()}, &resp); err != nil {
+		return nil, fmt.Errorf("remote sign hash failed: %w", err)
+	}
+	return decodeRemoteSignature(resp.Signature)
+}
+
+// SignTypedData sends the full typed data so the remote side can inspect what it signs
+func (s *RemoteSigner) SignTypedData(typedData *TypedData) ([]byte, error) {
+	var resp remoteSignatureResponse
+	if err := s.remote.call(http.MethodPost, RemoteSignerTypedDataPath, typedData, &resp); err != nil {
+		return nil, fmt.Errorf("remote sign typed data failed: %w", err)
+	}
+	return decodeRemoteSignature(resp.Signature)
+}
+
+// newRemoteClient creates a client for an HTTP URL or a unix:// socket endpoint
+func newRemoteClient(endpoint string, authToken string, timeout time.Duration) *remoteClient {
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+
+	httpClient := &http.Client{Timeout: timeout}
+	baseURL := strings.TrimSuffix(endpoint, "/")
+
+	// Dial the Unix socket for every request; the host part is ignored
+	if strings.HasPrefix(baseURL, "unix://") {
+		socketPath := strings.TrimPrefix(baseURL, "unix://")
+		httpClient.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		}
+		baseURL = "http://signer"
+	}
+
+	return &remoteClient{
+		baseURL:    baseURL,
+		authToken:  authToken,
+		httpClient: httpClient,
+	}
+}
+
+func (s *remoteClient) call(method string, path string, body interface{}, result interface{}) error {
+	var bodyReader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, s.baseURL+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.authToken)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		data, _ := io.ReadAll(resp.Body)
+		var errResp remoteErrorResponse
+		if json.Unmarshal(data, &errResp) == nil && errResp.Error != "" {
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, errResp.Error)
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(data))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func decodeRemoteSignature(signature string) ([]byte, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+	return normalizeSignature(sig)
+}
+
+// SignerHandlerConfig configures NewSignerHandler
+type SignerHandlerConfig struct {
+	// AuthToken is the bearer token clients must send. Without one, only requests that
+	// arrive over a Unix socket are served.
+	AuthToken string
+	// AllowHashSigning serves RemoteSignerHashPath, which signs any 32-byte digest without
+	// knowing what it authorizes. The SDK only needs typed-data signing; leave it off
+	// unless a client calls RemoteSigner.SignHash.
+	AllowHashSigning bool
+}
+
+// NewSignerHandler serves the remote signer protocol for a local Signer.
+// It is meant to run in a separate, locked-down process that owns the key.
+func NewSignerHandler(signer Signer, config *SignerHandlerConfig) http.Handler {
+	if config == nil {
+		config = &SignerHandlerConfig{}
+	}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(RemoteSignerAddressPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeSignerError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		writeSignerJSON(w, remoteAddressResponse{Address: signer.Address().Hex()})
+	})
+
+	if config.AllowHashSigning {
+		mux.HandleFunc(RemoteSignerHashPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				writeSignerError(w, http.StatusMethodNotAllowed, "method not allowed")
+				return
+			}
+
+			var req remoteHashRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeSignerError(w, http.StatusBadRequest, "invalid request body")
+				return
+			}
+			hash, err := hexutil.Decode(req.Hash)
+			if err != nil || len(hash) != common.HashLength {
+				writeSignerError(w, http.StatusBadRequest, "hash must be 32 bytes hex")
+				return
+			}
+
+			sig, err := signer.SignHash(common.BytesToHash(hash))
+			if err != nil {
+				writeSignerError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			writeSignerJSON(w, remoteSignatureResponse{Signature: hexutil.Encode(sig)})
+		})
+	}
+
+	mux.HandleFunc(RemoteSignerTypedDataPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeSignerError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+
+		var typedData TypedData
+		if err := decoder.Decode(&typedData); err != nil {
+			writeSignerError(w, http.StatusBadRequest, "invalid typed data")
+			return
+		}
+
+		sig, err := signer.SignTypedData(&typedData)
+		if err != nil {
+			writeSignerError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeSignerJSON(w, remoteSignatureResponse{Signature: hexutil.Encode(sig)})
+	})
+
+	return requireAuthorization(config.AuthToken, mux)
+}
+
+// requireAuthorization rejects requests without the expected bearer token. Without a
+// token, only requests over a Unix socket, whose file permissions restrict who can
+// connect, are let through.
+func requireAuthorization(authToken string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authToken == "" {
+			if !isUnixSocketRequest(r) {
+				writeSignerError(w, http.StatusUnauthorized, "an auth token is required outside Unix sockets")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(authToken)) != 1 {
+			writeSignerError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isUnixSocketRequest reports whether r arrived on a Unix socket listener
+func isUnixSocketRequest(r *http.Request) bool {
+	addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	return ok && addr.Network() == "unix"
+}
+
+func writeSignerJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeSignerError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(remoteErrorResponse{Error: message})
+}
@@ -0,0 +1,147 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	wallet := testWallet(t)
+	srv := httptest.NewServer(auth.NewSignerHandler(wallet.Signer(), &auth.SignerHandlerConfig{AuthToken: "secret"}))
+	defer srv.Close()
+
+	signer, err := auth.NewRemoteSigner(&auth.RemoteSignerConfig{Endpoint: srv.URL, AuthToken: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.Address() != wallet.GetAddress() {
+		t.Errorf("address = %s, want %s", signer.Address().Hex(), wallet.GetAddressHex())
+	}
+
+	// Typed data survives the JSON round trip and signs exactly like the local key
+	order := testOrder(testFunder, wallet.GetAddressHex(), types.SignatureTypePolyGnosisSafe)
+	remote, err := auth.BuildOrderSignature(signer, order, int64(types.ChainAmoy), amoyExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, err := auth.BuildOrderSignature(wallet.Signer(), order, int64(types.ChainAmoy), amoyExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remote != local {
+		t.Errorf("remote signature = %s, want %s", remote, local)
+	}
+
+	clobAuth, err := auth.BuildClobEip712Signature(signer, int64(types.ChainAmoy), 10000000, 23)
+	if err != nil || clobAuth != clobAuthSignature {
+		t.Errorf("remote ClobAuth signature = %s, %v", clobAuth, err)
+	}
+
+	// Hash signing is off unless the handler opts in
+	hash, _ := auth.BuildOrderHash(order, int64(types.ChainAmoy), amoyExchange)
+	if _, err := signer.SignHash(hash); err == nil {
+		t.Error("SignHash succeeded without AllowHashSigning")
+	}
+}
+
+func TestRemoteSignerHashSigning(t *testing.T) {
+	wallet := testWallet(t)
+	srv := httptest.NewServer(auth.NewSignerHandler(wallet.Signer(), &auth.SignerHandlerConfig{
+		AuthToken:        "secret",
+		AllowHashSigning: true,
+	}))
+	defer srv.Close()
+
+	signer, err := auth.NewRemoteSigner(&auth.RemoteSignerConfig{Endpoint: srv.URL, AuthToken: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order := testOrder(testFunder, wallet.GetAddressHex(), types.SignatureTypePolyProxy)
+	hash, _ := auth.BuildOrderHash(order, int64(types.ChainAmoy), amoyExchange)
+	signature, err := signer.SignHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := auth.RecoverAddress(hash, hexutil.Encode(signature))
+	if err != nil || recovered != wallet.GetAddress() {
+		t.Errorf("recovered %s, %v", recovered.Hex(), err)
+	}
+}
+
+func TestSignerHandlerRequiresToken(t *testing.T) {
+	wallet := testWallet(t)
+
+	srv := httptest.NewServer(auth.NewSignerHandler(wallet.Signer(), &auth.SignerHandlerConfig{AuthToken: "secret"}))
+	defer srv.Close()
+	for _, token := range []string{"", "wrong"} {
+		_, err := auth.NewRemoteSigner(&auth.RemoteSignerConfig{Endpoint: srv.URL, AuthToken: token})
+		if err == nil || !strings.Contains(err.Error(), "401") {
+			t.Errorf("token %q: err = %v, want a 401", token, err)
+		}
+	}
+
+	// Without a token the handler refuses anything that does not come over a Unix socket
+	open := httptest.NewServer(auth.NewSignerHandler(wallet.Signer(), nil))
+	defer open.Close()
+	resp, err := http.Get(open.URL + auth.RemoteSignerAddressPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("tokenless TCP request status = %d, want 401", resp.StatusCode)
+	}
+
+	if _, err := auth.ListenSigner("127.0.0.1:0", ""); err == nil {
+		t.Error("ListenSigner opened a TCP listener without a token")
+	}
+}
+
+func TestRemoteSignerUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix socket permissions are not enforced on Windows")
+	}
+	wallet := testWallet(t)
+	// Keep the path short; socket paths are limited to ~100 bytes
+	dir, err := os.MkdirTemp("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "s.sock")
+
+	listener, err := auth.ListenSigner("unix://"+socket, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: auth.NewSignerHandler(wallet.Signer(), nil)}
+	go srv.Serve(listener)
+	defer srv.Close()
+
+	info, err := os.Stat(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		t.Errorf("socket permissions = %o, want owner only", perm)
+	}
+
+	signer, err := auth.NewRemoteSigner(&auth.RemoteSignerConfig{Endpoint: "unix://" + socket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := auth.BuildClobEip712Signature(signer, int64(types.ChainAmoy), 10000000, 23)
+	if err != nil || signature != clobAuthSignature {
+		t.Errorf("signature over Unix socket = %s, %v", signature, err)
+	}
+}
@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// HashTypedData computes the EIP-712 digest of typed data with go-ethereum's encoder:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message))
+func HashTypedData(typedData *TypedData) (common.Hash, error) {
+	if typedData == nil {
+		return common.Hash{}, fmt.Errorf("typed data is required")
+	}
+
+	converted, err := toAPITypedData(typedData)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(*converted)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to hash %s: %w", typedData.PrimaryType, err)
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// toAPITypedData converts typed data to go-ethereum's representation, declaring the
+// EIP712Domain type from the domain fields that are set
+func toAPITypedData(typedData *TypedData) (*apitypes.TypedData, error) {
+	message, err := typedDataMessageMap(typedData.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	domainType := []apitypes.Type{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+	}
+	if typedData.Domain.VerifyingContract != "" {
+		domainType = append(domainType, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	if typedData.Domain.Salt != "" {
+		domainType = append(domainType, apitypes.Type{Name: "salt", Type: "bytes32"})
+	}
+
+	types := apitypes.Types{"EIP712Domain": domainType}
+	for name, fields := range typedData.Types {
+		if name == "EIP712Domain" {
+			continue
+		}
+		converted := make([]apitypes.Type, 0, len(fields))
+		for _, field := range fields {
+			converted = append(converted, apitypes.Type{Name: field.Name, Type: field.Type})
+		}
+		types[name] = converted
+	}
+
+	return &apitypes.TypedData{
+		Types:       types,
+		PrimaryType: typedData.PrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:              typedData.Domain.Name,
+			Version:           typedData.Domain.Version,
+			ChainId:           math.NewHexOrDecimal256(typedData.Domain.ChainID),
+			VerifyingContract: typedData.Domain.VerifyingContract,
+			Salt:              typedData.Domain.Salt,
+		},
+		Message: normalizeTypedValue(message).(map[string]interface{}),
+	}, nil
+}
+
+// typedDataMessageMap converts a message (struct or map) into a generic map
+func typedDataMessageMap(message interface{}) (map[string]interface{}, error) {
+	if m, ok := message.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	raw, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var result map[string]interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("message must be an object: %w", err)
+	}
+	return result, nil
+}
+
+// normalizeTypedValue converts JSON numbers and Go integers, which the go-ethereum encoder
+// does not accept, into decimal strings and big integers
+func normalizeTypedValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = normalizeTypedValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = normalizeTypedValue(item)
+		}
+		return out
+	case json.Number:
+		return v.String()
+	case int:
+		return big.NewInt(int64(v))
+	case int64:
+		return big.NewInt(v)
+	case uint64:
+		return new(big.Int).SetUint64(v)
+	case uint8:
+		return big.NewInt(int64(v))
+	}
+	return value
+}
@@ -0,0 +1,510 @@
+// Package catalog mirrors Gamma events, markets, tags and series into a local store with
+// indexes, so lookups by slug, condition ID, CLOB token ID, tag or end date do not need
+// a round trip to the API.
+//
+// The first Sync pages through every event with GetEventsPaginated; later calls only
+// fetch events whose updatedAt or lastActiveAt moved since the previous sync. With a
+// Path, the catalog is saved to a snapshot file after every sync and loaded by New.
+//
+//	cat, err := catalog.New(catalog.Config{SDK: gammaSDK, Path: "catalog.json"})
+//	if _, err := cat.Sync(ctx); err != nil { ... }
+//	go cat.Run(ctx, time.Minute, nil)
+//
+//	market, ok := cat.MarketByTokenID(tokenID)
+//	endingSoon := cat.Markets(catalog.MarketQuery{EndAfter: now, EndBefore: now.Add(24 * time.Hour)})
+//
+// Returned events and markets share memory with the catalog and must not be modified.
+package catalog
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/gamma"
+)
+
+const (
+	defaultPageSize = 100
+	defaultOverlap  = time.Minute
+)
+
+// Config configures a Catalog
+type Config struct {
+	// SDK is the Gamma client used by Sync
+	SDK *gamma.GammaSDK
+
+	// Path is the snapshot file; empty keeps the catalog in memory only
+	Path string
+
+	// Events per request (default 100)
+	PageSize int
+
+	// Query filters the events that are mirrored (default all events). Limit, Offset,
+	// Order and Ascending are set by the catalog.
+	Query gamma.PaginatedEventQuery
+
+	// Overlap is subtracted from the previous sync time in incremental syncs, to cover
+	// clock skew and in-flight updates (default 1 minute)
+	Overlap time.Duration
+}
+
+// MarketQuery filters Markets. Zero fields do not filter.
+type MarketQuery struct {
+	// Tag is a tag slug, label or ID of the market's event
+	Tag string
+
+	// EndAfter and EndBefore bound the market's end date (inclusive, exclusive)
+	EndAfter  time.Time
+	EndBefore time.Time
+
+	Active *bool
+	Closed *bool
+
+	// Stop after this many markets (0 = no cap)
+	Limit int
+}
+
+// Stats counts the catalog's contents
+type Stats struct {
+	Events   int
+	Markets  int
+	Tags     int
+	Series   int
+	SyncedAt time.Time
+}
+
+// Catalog is a local, indexed mirror of Gamma. It is safe for concurrent use.
+type Catalog struct {
+	config Config
+
+	syncMu sync.Mutex // serializes Sync
+
+	mu       sync.RWMutex
+	syncedAt time.Time
+
+	events  map[string]*gamma.Event  // by event ID
+	markets map[string]*gamma.Market // by market ID, pointing into events
+	tags    map[string]gamma.UpdatedTag
+	series  map[string]gamma.Series
+
+	marketEvent   map[string]string // market ID -> event ID
+	eventSlugs    map[string]string // slug -> event ID
+	marketSlugs   map[string]string // slug -> market ID
+	conditionIDs  map[string]string // condition ID -> market ID
+	tokenIDs      map[string]string // CLOB token ID -> market ID
+	tagEvents     map[string]map[string]struct{}
+	tickers       map[string]string // ticker -> event ID
+	tagSlugs      map[string]string // tag slug -> tag ID
+	seriesSlugs   map[string]string // series slug -> series ID
+	endDates      []string          // market IDs by end date; see sortEndDates
+	endDatesDirty bool
+}
+
+// New creates a catalog and loads its snapshot, if Path names an existing file
+func New(config Config) (*Catalog, error) {
+	if config.PageSize <= 0 {
+		config.PageSize = defaultPageSize
+	}
+	if config.Overlap <= 0 {
+		config.Overlap = defaultOverlap
+	}
+
+	c := &Catalog{config: config}
+	c.reset()
+	if config.Path != "" {
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *Catalog) reset() {
+	c.syncedAt = time.Time{}
+	c.events = make(map[string]*gamma.Event)
+	c.markets = make(map[string]*gamma.Market)
+	c.tags = make(map[string]gamma.UpdatedTag)
+	c.series = make(map[string]gamma.Series)
+	c.marketEvent = make(map[string]string)
+	c.eventSlugs = make(map[string]string)
+	c.marketSlugs = make(map[string]string)
+	c.conditionIDs = make(map[string]string)
+	c.tokenIDs = make(map[string]string)
+	c.tagEvents = make(map[string]map[string]struct{})
+	c.endDates = nil
+	c.endDatesDirty = true
+	c.tagSlugs = make(map[string]string)
+	c.seriesSlugs = make(map[string]string)
+	c.tickers = make(map[string]string)
+}
+
+// putEvent adds or replaces an event and its markets; callers hold mu
+func (c *Catalog) putEvent(event gamma.Event) {
+	if old, ok := c.events[event.ID]; ok {
+		c.removeEvent(old)
+	}
+
+	stored := &event
+	c.events[event.ID] = stored
+	if event.Slug != "" {
+		c.eventSlugs[event.Slug] = event.ID
+	}
+	if event.Ticker != "" {
+		c.tickers[event.Ticker] = event.ID
+	}
+	for _, key := range tagKeys(event.Tags) {
+		ids := c.tagEvents[key]
+		if ids == nil {
+			ids = make(map[string]struct{})
+			c.tagEvents[key] = ids
+		}
+		ids[event.ID] = struct{}{}
+	}
+
+	for i := range stored.Markets {
+		market := &stored.Markets[i]
+		c.markets[market.ID] = market
+		c.marketEvent[market.ID] = event.ID
+		if market.Slug != "" {
+			c.marketSlugs[market.Slug] = market.ID
+		}
+		if market.ConditionID != "" {
+			c.conditionIDs[strings.ToLower(market.ConditionID)] = market.ID
+		}
+		for _, tokenID := range market.ClobTokenIDs {
+			c.tokenIDs[tokenID] = market.ID
+		}
+	}
+	c.endDatesDirty = true
+}
+
+// removeEvent drops an event, its markets and their index entries; callers hold mu
+func (c *Catalog) removeEvent(event *gamma.Event) {
+	delete(c.events, event.ID)
+	if c.eventSlugs[event.Slug] == event.ID {
+		delete(c.eventSlugs, event.Slug)
+	}
+	if c.tickers[event.Ticker] == event.ID {
+		delete(c.tickers, event.Ticker)
+	}
+	for _, key := range tagKeys(event.Tags) {
+		delete(c.tagEvents[key], event.ID)
+		if len(c.tagEvents[key]) == 0 {
+			delete(c.tagEvents, key)
+		}
+	}
+
+	for i := range event.Markets {
+		market := &event.Markets[i]
+		if c.marketEvent[market.ID] != event.ID {
+			continue
+		}
+		delete(c.markets, market.ID)
+		delete(c.marketEvent, market.ID)
+		if c.marketSlugs[market.Slug] == market.ID {
+			delete(c.marketSlugs, market.Slug)
+		}
+		conditionID := strings.ToLower(market.ConditionID)
+		if c.conditionIDs[conditionID] == market.ID {
+			delete(c.conditionIDs, conditionID)
+		}
+		for _, tokenID := range market.ClobTokenIDs {
+			if c.tokenIDs[tokenID] == market.ID {
+				delete(c.tokenIDs, tokenID)
+			}
+		}
+	}
+	c.endDatesDirty = true
+}
+
+// putTag adds or replaces a tag; callers hold mu
+func (c *Catalog) putTag(tag gamma.UpdatedTag) {
+	c.tags[tag.ID] = tag
+	if tag.Slug != "" {
+		c.tagSlugs[tag.Slug] = tag.ID
+	}
+}
+
+// putSeries adds or replaces a series; callers hold mu
+func (c *Catalog) putSeries(series gamma.Series) {
+	c.series[series.ID] = series
+	if series.Slug != "" {
+		c.seriesSlugs[series.Slug] = series.ID
+	}
+}
+
+// tagKeys returns the keys an event is indexed under for its tags: ID, slug and
+// lowercase label
+func tagKeys(tags []gamma.Tag) []string {
+	keys := make([]string, 0, 3*len(tags))
+	for _, tag := range tags {
+		for _, key := range []string{tag.ID, tag.Slug, strings.ToLower(tag.Label)} {
+			if key != "" {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// Stats counts the catalog's contents
+func (c *Catalog) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return Stats{
+		Events:   len(c.events),
+		Markets:  len(c.markets),
+		Tags:     len(c.tags),
+		Series:   len(c.series),
+		SyncedAt: c.syncedAt,
+	}
+}
+
+// SyncedAt returns when the last successful sync started (zero before the first)
+func (c *Catalog) SyncedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.syncedAt
+}
+
+// Event returns the event with the given ID
+func (c *Catalog) Event(id string) (*gamma.Event, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	event, ok := c.events[id]
+	return event, ok
+}
+
+// EventBySlug returns the event with the given slug
+func (c *Catalog) EventBySlug(slug string) (*gamma.Event, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	event, ok := c.events[c.eventSlugs[slug]]
+	return event, ok
+}
+
+// EventByTicker returns the event with the given ticker
+func (c *Catalog) EventByTicker(ticker string) (*gamma.Event, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	event, ok := c.events[c.tickers[ticker]]
+	return event, ok
+}
+
+// EventsByTag returns the events tagged with the given tag slug, label or ID, by ID
+func (c *Catalog) EventsByTag(tag string) []*gamma.Event {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := c.eventIDsByTag(tag)
+	events := make([]*gamma.Event, 0, len(ids))
+	for _, id := range ids {
+		events = append(events, c.events[id])
+	}
+	return events
+}
+
+// eventIDsByTag returns the sorted IDs of the events with a tag; callers hold mu
+func (c *Catalog) eventIDsByTag(tag string) []string {
+	set := c.tagEvents[tag]
+	if set == nil {
+		set = c.tagEvents[strings.ToLower(tag)]
+	}
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Market returns the market with the given ID
+func (c *Catalog) Market(id string) (*gamma.Market, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	market, ok := c.markets[id]
+	return market, ok
+}
+
+// MarketBySlug returns the market with the given slug
+func (c *Catalog) MarketBySlug(slug string) (*gamma.Market, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	market, ok := c.markets[c.marketSlugs[slug]]
+	return market, ok
+}
+
+// MarketByConditionID returns the market with the given condition ID, in any case
+func (c *Catalog) MarketByConditionID(conditionID string) (*gamma.Market, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	market, ok := c.markets[c.conditionIDs[strings.ToLower(conditionID)]]
+	return market, ok
+}
+
+// MarketByTokenID returns the market that one of whose outcomes has the given CLOB token
+func (c *Catalog) MarketByTokenID(tokenID string) (*gamma.Market, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	market, ok := c.markets[c.tokenIDs[tokenID]]
+	return market, ok
+}
+
+// EventOf returns the event a market belongs to
+func (c *Catalog) EventOf(marketID string) (*gamma.Event, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	event, ok := c.events[c.marketEvent[marketID]]
+	return event, ok
+}
+
+// Markets returns the markets matching the query, by end date and then ID. Markets
+// without an end date come last, and never match EndAfter or EndBefore.
+func (c *Catalog) Markets(query MarketQuery) []*gamma.Market {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var tagged map[string]struct{}
+	if query.Tag != "" {
+		tagged = make(map[string]struct{})
+		for _, id := range c.eventIDsByTag(query.Tag) {
+			tagged[id] = struct{}{}
+		}
+	}
+
+	// Binary search the end date index for the first candidate
+	start := 0
+	if !query.EndAfter.IsZero() {
+		start = sort.Search(len(c.endDates), func(i int) bool {
+			end := c.markets[c.endDates[i]].EndDate
+			return !end.IsZero() && !end.Before(query.EndAfter)
+		})
+	}
+
+	var markets []*gamma.Market
+	for _, id := range c.endDates[start:] {
+		if query.Limit > 0 && len(markets) >= query.Limit {
+			break
+		}
+		market := c.markets[id]
+		if market.EndDate.IsZero() && (!query.EndAfter.IsZero() || !query.EndBefore.IsZero()) {
+			break
+		}
+		if !query.EndBefore.IsZero() && !market.EndDate.Before(query.EndBefore) {
+			break
+		}
+		if tagged != nil {
+			if _, ok := tagged[c.marketEvent[id]]; !ok {
+				continue
+			}
+		}
+		if query.Active != nil && market.Active != *query.Active {
+			continue
+		}
+		if query.Closed != nil && market.Closed != *query.Closed {
+			continue
+		}
+		markets = append(markets, market)
+	}
+	return markets
+}
+
+// sortEndDates rebuilds the end date index if markets changed; writers call it before
+// releasing mu
+func (c *Catalog) sortEndDates() {
+	if !c.endDatesDirty {
+		return
+	}
+
+	ids := make([]string, 0, len(c.markets))
+	for id := range c.markets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := c.markets[ids[i]].EndDate, c.markets[ids[j]].EndDate
+		switch {
+		case a.IsZero() != b.IsZero():
+			return b.IsZero()
+		case !a.Equal(b):
+			return a.Before(b)
+		default:
+			return ids[i] < ids[j]
+		}
+	})
+	c.endDates = ids
+	c.endDatesDirty = false
+}
+
+// Tag returns the tag with the given ID
+func (c *Catalog) Tag(id string) (gamma.UpdatedTag, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	tag, ok := c.tags[id]
+	return tag, ok
+}
+
+// TagBySlug returns the tag with the given slug
+func (c *Catalog) TagBySlug(slug string) (gamma.UpdatedTag, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	tag, ok := c.tags[c.tagSlugs[slug]]
+	return tag, ok
+}
+
+// Tags returns all tags, by ID
+func (c *Catalog) Tags() []gamma.UpdatedTag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	tags := make([]gamma.UpdatedTag, 0, len(c.tags))
+	for _, tag := range c.tags {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].ID < tags[j].ID })
+	return tags
+}
+
+// Series returns the series with the given ID
+func (c *Catalog) Series(id string) (gamma.Series, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	series, ok := c.series[id]
+	return series, ok
+}
+
+// SeriesBySlug returns the series with the given slug
+func (c *Catalog) SeriesBySlug(slug string) (gamma.Series, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	series, ok := c.series[c.seriesSlugs[slug]]
+	return series, ok
+}
+
+// AllSeries returns all series, by ID
+func (c *Catalog) AllSeries() []gamma.Series {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	all := make([]gamma.Series, 0, len(c.series))
+	for _, series := range c.series {
+		all = append(all, series)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all
+}
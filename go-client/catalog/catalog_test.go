@@ -0,0 +1,323 @@
+package catalog_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/catalog"
+	"github.com/HuakunShen/polymarket-kit/go-client/gamma"
+)
+
+// gammaServer serves /events/pagination, /tags and /series from in-memory events
+type gammaServer struct {
+	mu       sync.Mutex
+	events   []map[string]interface{}
+	requests []string
+
+	// holdTags, if set, delays /tags responses until it is closed
+	holdTags chan struct{}
+}
+
+func (s *gammaServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/tags" && s.holdTags != nil {
+		<-s.holdTags
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := req.URL.Query()
+	s.requests = append(s.requests, req.URL.Path+"?"+query.Get("order"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	var body interface{}
+	switch req.URL.Path {
+	case "/events/pagination":
+		events := append([]map[string]interface{}(nil), s.events...)
+		order := query.Get("order")
+		sort.SliceStable(events, func(i, j int) bool {
+			a, b := fmt.Sprint(events[i][order]), fmt.Sprint(events[j][order])
+			if query.Get("ascending") == "true" {
+				return a < b
+			}
+			return a > b
+		})
+		end := offset + limit
+		if end > len(events) {
+			end = len(events)
+		}
+		if offset > end {
+			offset = end
+		}
+		body = map[string]interface{}{
+			"data":       events[offset:end],
+			"pagination": map[string]bool{"hasMore": end < len(events)},
+		}
+	case "/tags":
+		body = []gamma.UpdatedTag{{ID: "2", Label: "Politics", Slug: "politics"}}
+		if offset > 0 {
+			body = []gamma.UpdatedTag{}
+		}
+	case "/series":
+		// As the API sends them: competitive is a string
+		body = []map[string]interface{}{{
+			"id": "9", "ticker": "fed", "slug": "fed", "title": "Fed", "active": true,
+			"volume": 1250000.5, "competitive": "0.87", "createdAt": "2023-11-30T18:02:11.51Z",
+		}}
+		if offset > 0 {
+			body = []map[string]interface{}{}
+		}
+	default:
+		return response(http.StatusNotFound, `{"error":"not found"}`), nil
+	}
+
+	data, _ := json.Marshal(body)
+	return response(http.StatusOK, string(data)), nil
+}
+
+func (s *gammaServer) set(events ...map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = events
+	s.requests = nil
+}
+
+func (s *gammaServer) eventRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, r := range s.requests {
+		if strings.HasPrefix(r, "/events/") {
+			n++
+		}
+	}
+	return n
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// event builds an event in the API's format, part of series 9, with one market per
+// entry of markets
+func event(id string, updatedAt string, tags []string, markets ...map[string]interface{}) map[string]interface{} {
+	var tagList []map[string]string
+	for _, tag := range tags {
+		tagList = append(tagList, map[string]string{"id": tag, "slug": tag, "label": strings.ToUpper(tag)})
+	}
+	return map[string]interface{}{
+		"id":           id,
+		"slug":         "event-" + id,
+		"title":        "Event " + id,
+		"updatedAt":    updatedAt,
+		"lastActiveAt": updatedAt,
+		"tags":         tagList,
+		"series":       []map[string]interface{}{{"id": "9", "slug": "fed", "competitive": "0", "volume": 1250000.5}},
+		"markets":      markets,
+	}
+}
+
+func market(id string, endDate string, closed bool) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            id,
+		"slug":          "market-" + id,
+		"conditionId":   "0xC" + id,
+		"endDate":       endDate,
+		"closed":        closed,
+		"active":        true,
+		"outcomes":      `["Yes", "No"]`,
+		"outcomePrices": `["0.5", "0.5"]`,
+		"clobTokenIds":  fmt.Sprintf(`["%s1", "%s2"]`, id, id),
+	}
+}
+
+func newCatalog(t *testing.T, srv *gammaServer, path string) *catalog.Catalog {
+	t.Helper()
+	cat, err := catalog.New(catalog.Config{
+		SDK:      gamma.NewGammaSDK(&gamma.GammaSDKConfig{Transport: srv}),
+		Path:     path,
+		PageSize: 2,
+		Overlap:  time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cat
+}
+
+func TestCatalogSyncAndQueries(t *testing.T) {
+	ctx := context.Background()
+	old := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	srv := &gammaServer{}
+	srv.set(
+		event("1", old, []string{"politics"}, market("10", "2030-01-01", false), market("11", "2030-03-01", true)),
+		event("2", old, []string{"sports"}, market("20", "2030-02-01", false)),
+		event("3", old, nil, market("30", "", false)),
+	)
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	cat := newCatalog(t, srv, path)
+
+	result, err := cat.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Full || result.Events != 3 || result.Markets != 4 || result.Requests != 2 {
+		t.Fatalf("first sync = %+v", result)
+	}
+	if stats := cat.Stats(); stats.Tags != 1 || stats.Series != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	if s, ok := cat.SeriesBySlug("fed"); !ok || s.Competitive == nil || *s.Competitive != 0.87 {
+		t.Errorf("SeriesBySlug = %+v, %v", s, ok)
+	}
+	if e, ok := cat.Event("1"); !ok || len(e.Series) != 1 || e.Series[0].ID != "9" {
+		t.Errorf("event series = %+v", e)
+	}
+	if e, ok := cat.EventBySlug("event-2"); !ok || e.ID != "2" {
+		t.Errorf("EventBySlug = %v, %v", e, ok)
+	}
+	if m, ok := cat.MarketByConditionID("0xc11"); !ok || m.ID != "11" {
+		t.Errorf("MarketByConditionID = %v, %v", m, ok)
+	}
+	if m, ok := cat.MarketByTokenID("202"); !ok || m.ID != "20" {
+		t.Errorf("MarketByTokenID = %v, %v", m, ok)
+	}
+	if e, ok := cat.EventOf("11"); !ok || e.ID != "1" {
+		t.Errorf("EventOf = %v, %v", e, ok)
+	}
+	if events := cat.EventsByTag("POLITICS"); len(events) != 1 || events[0].ID != "1" {
+		t.Errorf("EventsByTag = %v", events)
+	}
+
+	ids := func(markets []*gamma.Market) string {
+		var ids []string
+		for _, m := range markets {
+			ids = append(ids, m.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+	if got := ids(cat.Markets(catalog.MarketQuery{})); got != "10,20,11,30" {
+		t.Errorf("all markets = %s", got)
+	}
+	open := false
+	got := ids(cat.Markets(catalog.MarketQuery{
+		EndAfter:  time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC),
+		EndBefore: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+		Closed:    &open,
+	}))
+	if got != "20" {
+		t.Errorf("open markets ending in 2030 after Jan 15 = %s", got)
+	}
+	if got := ids(cat.Markets(catalog.MarketQuery{Tag: "politics", Limit: 1})); got != "10" {
+		t.Errorf("politics markets = %s", got)
+	}
+
+	// Event 2 drops market 20 for 21; event 3 is untouched
+	now := time.Now().UTC().Format(time.RFC3339)
+	srv.set(
+		event("2", now, []string{"sports"}, market("21", "2029-12-01", false)),
+		event("1", old, []string{"politics"}, market("10", "2030-01-01", false), market("11", "2030-03-01", true)),
+		event("3", old, nil, market("30", "", false)),
+	)
+	result, err = cat.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Full || result.Events != 1 {
+		t.Fatalf("incremental sync = %+v", result)
+	}
+	if n := srv.eventRequests(); n != 2 {
+		t.Errorf("incremental sync made %d event requests, want one per order", n)
+	}
+	if _, ok := cat.MarketByTokenID("202"); ok {
+		t.Error("market 20 is still indexed after its event dropped it")
+	}
+	if got := ids(cat.Markets(catalog.MarketQuery{})); got != "21,10,11,30" {
+		t.Errorf("markets after incremental sync = %s", got)
+	}
+
+	// A new catalog loads the snapshot and syncs incrementally
+	reloaded := newCatalog(t, srv, path)
+	if m, ok := reloaded.MarketBySlug("market-21"); !ok || len(m.Outcomes()) != 2 || m.Outcomes()[1].TokenID != "212" {
+		t.Fatalf("reloaded market = %+v, %v", m, ok)
+	}
+	if got := ids(reloaded.Markets(catalog.MarketQuery{})); got != "21,10,11,30" {
+		t.Errorf("reloaded markets = %s", got)
+	}
+	if !reloaded.SyncedAt().Equal(cat.SyncedAt()) {
+		t.Errorf("reloaded SyncedAt = %v, want %v", reloaded.SyncedAt(), cat.SyncedAt())
+	}
+
+	// A full sync drops events that disappeared
+	srv.set(event("1", now, []string{"politics"}, market("10", "2030-01-01", false)))
+	if _, err := reloaded.FullSync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if stats := reloaded.Stats(); stats.Events != 1 || stats.Markets != 1 {
+		t.Errorf("stats after full sync = %+v", stats)
+	}
+}
+
+func TestCatalogSyncFailureKeepsData(t *testing.T) {
+	srv := &gammaServer{}
+	srv.set(event("1", time.Now().UTC().Format(time.RFC3339), nil, market("10", "2030-01-01", false)))
+	cat := newCatalog(t, srv, "")
+	if _, err := cat.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cat.FullSync(ctx); err == nil {
+		t.Fatal("expected an error from a cancelled sync")
+	}
+	if _, ok := cat.Market("10"); !ok {
+		t.Error("a failed sync dropped stored markets")
+	}
+}
+
+func TestCatalogSyncCancelledDuringTags(t *testing.T) {
+	srv := &gammaServer{holdTags: make(chan struct{})}
+	t.Cleanup(func() { close(srv.holdTags) })
+	srv.set(event("1", time.Now().UTC().Format(time.RFC3339), nil, market("10", "2030-01-01", false)))
+	cat := newCatalog(t, srv, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := cat.Sync(ctx)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Sync = %v, want the context's error", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Sync did not return when its context expired")
+	}
+	if _, ok := cat.Market("10"); ok {
+		t.Error("a cancelled sync stored events")
+	}
+}
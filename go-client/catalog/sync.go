@@ -0,0 +1,288 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/gamma"
+)
+
+// snapshotVersion is bumped when the snapshot layout changes; older snapshots are ignored
+const snapshotVersion = 1
+
+// SyncResult describes one sync
+type SyncResult struct {
+	// Full is true when every event was fetched, false for an incremental sync
+	Full bool
+
+	// Events fetched and stored, including their markets
+	Events  int
+	Markets int
+
+	Tags   int
+	Series int
+
+	// Requests made to the events endpoint
+	Requests int
+
+	Duration time.Duration
+}
+
+// snapshot is the on-disk form of a catalog
+type snapshot struct {
+	Version  int                `json:"version"`
+	SyncedAt time.Time          `json:"syncedAt"`
+	Events   []gamma.Event      `json:"events"`
+	Tags     []gamma.UpdatedTag `json:"tags"`
+	Series   []gamma.Series     `json:"series"`
+}
+
+// Sync brings the catalog up to date: a full sync the first time, an incremental one
+// afterwards. Tags and series are always fetched in full. Nothing is stored if a
+// request fails.
+func (c *Catalog) Sync(ctx context.Context) (*SyncResult, error) {
+	return c.sync(ctx, c.SyncedAt().IsZero())
+}
+
+// FullSync refetches every event, dropping events that no longer match Query or were
+// deleted, which incremental syncs cannot notice
+func (c *Catalog) FullSync(ctx context.Context) (*SyncResult, error) {
+	return c.sync(ctx, true)
+}
+
+// Run syncs every interval until ctx is done, and returns ctx's error. Failed syncs are
+// retried at the next tick; onError, if set, receives their errors.
+func (c *Catalog) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Sync(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Catalog) sync(ctx context.Context, full bool) (*SyncResult, error) {
+	if c.config.SDK == nil {
+		return nil, errors.New("catalog: no SDK configured")
+	}
+
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	started := time.Now()
+	result := &SyncResult{Full: full}
+
+	var events []gamma.Event
+	var err error
+	if full {
+		events, err = c.fetchEvents(ctx, "id", true, time.Time{}, nil, result)
+	} else {
+		events, err = c.fetchChangedEvents(ctx, c.SyncedAt().Add(-c.config.Overlap), result)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	iteratorOptions := &gamma.IteratorOptions{PageSize: c.config.PageSize}
+	tags, err := collect(ctx, c.config.SDK.IterateTags(gamma.TagQuery{}, iteratorOptions))
+	if err != nil {
+		return nil, fmt.Errorf("catalog: fetching tags: %w", err)
+	}
+	series, err := collect(ctx, c.config.SDK.IterateSeries(gamma.SeriesQuery{}, iteratorOptions))
+	if err != nil {
+		return nil, fmt.Errorf("catalog: fetching series: %w", err)
+	}
+
+	c.mu.Lock()
+	if full {
+		c.reset()
+	}
+	for _, event := range events {
+		c.putEvent(event)
+		result.Markets += len(event.Markets)
+	}
+	for _, tag := range tags {
+		c.putTag(tag)
+	}
+	for _, s := range series {
+		c.putSeries(s)
+	}
+	c.sortEndDates()
+	c.syncedAt = started
+	c.mu.Unlock()
+
+	result.Events = len(events)
+	result.Tags = len(tags)
+	result.Series = len(series)
+	result.Duration = time.Since(started)
+
+	if c.config.Path != "" {
+		if err := c.Save(); err != nil {
+			return result, fmt.Errorf("catalog: saving snapshot: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// fetchChangedEvents fetches the events whose updatedAt or lastActiveAt is at or after
+// since, newest first for each field
+func (c *Catalog) fetchChangedEvents(ctx context.Context, since time.Time, result *SyncResult) ([]gamma.Event, error) {
+	updated, err := c.fetchEvents(ctx, "updatedAt", false, since, func(e *gamma.Event) time.Time {
+		return e.UpdatedAt
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+	active, err := c.fetchEvents(ctx, "lastActiveAt", false, since, func(e *gamma.Event) time.Time {
+		return e.LastActiveAt
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(updated))
+	for _, event := range updated {
+		seen[event.ID] = true
+	}
+	for _, event := range active {
+		if !seen[event.ID] {
+			updated = append(updated, event)
+		}
+	}
+	return updated, nil
+}
+
+// collect gathers the items of it, stopping it when ctx is done
+func collect[T any](ctx context.Context, it *gamma.Iterator[T]) ([]T, error) {
+	stop := context.AfterFunc(ctx, it.Stop)
+	defer stop()
+
+	items, err := it.All()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return items, err
+}
+
+// fetchEvents pages through GetEventsPaginated in the given order. With a stamp, paging
+// stops at the first event whose stamp is before since.
+func (c *Catalog) fetchEvents(ctx context.Context, order string, ascending bool, since time.Time,
+	stamp func(*gamma.Event) time.Time, result *SyncResult) ([]gamma.Event, error) {
+	query := c.config.Query
+	limit := c.config.PageSize
+	query.Limit = &limit
+	query.Order = &order
+	query.Ascending = &ascending
+
+	var events []gamma.Event
+	for offset := 0; ; offset += limit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		page := offset
+		query.Offset = &page
+		resp, err := c.config.SDK.GetEventsPaginated(query)
+		result.Requests++
+		if err != nil {
+			return nil, fmt.Errorf("catalog: fetching events at offset %d: %w", offset, err)
+		}
+
+		for i := range resp.Data {
+			if stamp != nil && stamp(&resp.Data[i]).Before(since) {
+				return events, nil
+			}
+			events = append(events, resp.Data[i])
+		}
+		if !resp.Pagination.HasMore || len(resp.Data) == 0 {
+			return events, nil
+		}
+	}
+}
+
+// Save writes the catalog to its snapshot file atomically
+func (c *Catalog) Save() error {
+	if c.config.Path == "" {
+		return errors.New("catalog: no snapshot path configured")
+	}
+
+	c.mu.RLock()
+	snap := snapshot{
+		Version:  snapshotVersion,
+		SyncedAt: c.syncedAt,
+		Events:   make([]gamma.Event, 0, len(c.events)),
+		Tags:     make([]gamma.UpdatedTag, 0, len(c.tags)),
+		Series:   make([]gamma.Series, 0, len(c.series)),
+	}
+	for _, event := range c.events {
+		snap.Events = append(snap.Events, *event)
+	}
+	for _, tag := range c.tags {
+		snap.Tags = append(snap.Tags, tag)
+	}
+	for _, series := range c.series {
+		snap.Series = append(snap.Series, series)
+	}
+	data, err := json.Marshal(snap)
+	c.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.config.Path), 0755); err != nil {
+		return err
+	}
+	tmp := c.config.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.config.Path)
+}
+
+// load reads the snapshot file, if it exists; a snapshot of another version is ignored
+// and the next Sync is a full one
+func (c *Catalog) load() error {
+	data, err := os.ReadFile(c.config.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("catalog: reading snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("catalog: decoding snapshot %s: %w", c.config.Path, err)
+	}
+	if snap.Version != snapshotVersion {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, event := range snap.Events {
+		c.putEvent(event)
+	}
+	for _, tag := range snap.Tags {
+		c.putTag(tag)
+	}
+	for _, series := range snap.Series {
+		c.putSeries(series)
+	}
+	c.sortEndDates()
+	c.syncedAt = snap.SyncedAt
+	return nil
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// ApiKeyRotation describes a key rotation of one account
+type ApiKeyRotation struct {
+	Address string
+	Nonce   uint64
+	OldKey  string
+	NewKey  string
+	// Revoked reports whether the old key was deleted
+	Revoked bool
+}
+
+// RotateApiKey creates an API key with a fresh nonce, switches the client over to it and
+// revokes the old key once every request signed with the old key has finished.
+//
+// Requests started after the switch use the new key, so trading continues throughout.
+// The new key is saved to the credential store with its nonce, so re-deriving it after
+// a restart finds the rotated key rather than the nonce 0 one.
+// ctx bounds the wait for in-flight requests; if it expires the new key stays active and
+// the old key is left in place (Revoked is false) so it can be revoked later with RevokeApiKey.
+func (c *ClobClient) RotateApiKey(ctx context.Context) (*ApiKeyRotation, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	old := c.getCreds()
+	if old == nil {
+		return nil, fmt.Errorf("API credentials are required")
+	}
+
+	// Each nonce maps to one key, so a millisecond timestamp always yields a new one
+	nonce := uint64(time.Now().UnixMilli())
+	creds, err := c.CreateApiKey(&nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+	if creds.Key == "" {
+		return nil, fmt.Errorf("failed to create API key: empty key returned")
+	}
+
+	inFlight := c.setCreds(creds)
+
+	rotation := &ApiKeyRotation{
+		Address: c.GetSignerAddress(),
+		Nonce:   nonce,
+		OldKey:  old.Key,
+		NewKey:  creds.Key,
+	}
+
+	// Keep the old key alive if the new one could not be persisted, otherwise the
+	// next boot would load a revoked key
+	if c.credentialStore != nil {
+		if err := c.credentialStore.Save(c.credentialKey(), creds); err != nil {
+			return rotation, fmt.Errorf("failed to save API credentials, old key not revoked: %w", err)
+		}
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		return rotation, fmt.Errorf("old API key not revoked: %w", ctx.Err())
+	}
+
+	if _, err := c.deleteApiKey(old); err != nil {
+		return rotation, fmt.Errorf("failed to revoke old API key: %w", err)
+	}
+	rotation.Revoked = true
+
+	return rotation, nil
+}
+
+// RevokeApiKey deletes the API key of the given credentials. Use DeleteApiKey to
+// delete the client's current key.
+func (c *ClobClient) RevokeApiKey(creds *types.ApiKeyCreds) error {
+	if creds == nil {
+		return fmt.Errorf("API credentials are required")
+	}
+	if current := c.getCreds(); current != nil && current.Key == creds.Key {
+		_, err := c.DeleteApiKey()
+		return err
+	}
+
+	_, err := c.deleteApiKey(creds)
+	return err
+}
+
+// KeyManager manages the API keys of a set of wallets, one client per wallet
+type KeyManager struct {
+	clients []*ClobClient
+	mu      sync.Mutex
+}
+
+// NewKeyManager creates a key manager for the given clients
+func NewKeyManager(clients ...*ClobClient) *KeyManager {
+	return &KeyManager{clients: clients}
+}
+
+// Clients returns the managed clients
+func (m *KeyManager) Clients() []*ClobClient {
+	return m.clients
+}
+
+// ListApiKeys lists the API keys of every account, keyed by signer address
+func (m *KeyManager) ListApiKeys() (map[string]*types.ApiKeysResponse, error) {
+	result := make(map[string]*types.ApiKeysResponse, len(m.clients))
+	err := m.forEach(func(c *ClobClient) error {
+		keys, err := c.GetApiKeys()
+		if err != nil {
+			return err
+		}
+		m.store(func() { result[c.GetSignerAddress()] = keys })
+		return nil
+	})
+	return result, err
+}
+
+// RotateAll rotates the API key of every account concurrently, keyed by signer address.
+// A failure on one account does not stop the others; all errors are returned joined.
+func (m *KeyManager) RotateAll(ctx context.Context) (map[string]*ApiKeyRotation, error) {
+	result := make(map[string]*ApiKeyRotation, len(m.clients))
+	err := m.forEach(func(c *ClobClient) error {
+		rotation, err := c.RotateApiKey(ctx)
+		if rotation != nil {
+			m.store(func() { result[c.GetSignerAddress()] = rotation })
+		}
+		return err
+	})
+	return result, err
+}
+
+// RevokeAll deletes the current API key of every account
+func (m *KeyManager) RevokeAll() error {
+	return m.forEach(func(c *ClobClient) error {
+		_, err := c.DeleteApiKey()
+		return err
+	})
+}
+
+// store serializes writes to result maps shared by forEach workers
+func (m *KeyManager) store(write func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	write()
+}
+
+// forEach runs fn for every client concurrently and joins the errors by account
+func (m *KeyManager) forEach(fn func(c *ClobClient) error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(m.clients))
+
+	for i, c := range m.clients {
+		wg.Add(1)
+		go func(i int, c *ClobClient) {
+			defer wg.Done()
+			if err := fn(c); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", c.GetSignerAddress(), err)
+			}
+		}(i, c)
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
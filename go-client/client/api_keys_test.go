@@ -0,0 +1,229 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/clobtest"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const otherKey = "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+
+func newStoreClient(t *testing.T, srv *clobtest.Server, privateKey string, store auth.CredentialStore) *client.ClobClient {
+	t.Helper()
+
+	clobClient, err := client.NewClobClient(&client.ClientConfig{
+		Host:            srv.URL,
+		ChainID:         types.ChainPolygon,
+		PrivateKey:      privateKey,
+		CredentialStore: store,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clobClient
+}
+
+// slowApiKeysRequest starts a GetApiKeys request that the server holds for delay and
+// returns once the server has received it
+func slowApiKeysRequest(t *testing.T, srv *clobtest.Server, clobClient *client.ClobClient, delay time.Duration) <-chan error {
+	t.Helper()
+
+	srv.AddFault(clobtest.Fault{Method: http.MethodGet, Path: client.GetApiKeys, Delay: delay, Times: 1})
+	done := make(chan error, 1)
+	go func() {
+		_, err := clobClient.GetApiKeys()
+		done <- err
+	}()
+	waitFor(t, func() bool { return countRequests(srv, http.MethodGet, client.GetApiKeys) == 1 })
+	return done
+}
+
+func countRequests(srv *clobtest.Server, method string, path string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == method && r.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+func serverKeys(t *testing.T, clobClient *client.ClobClient) []string {
+	t.Helper()
+
+	keys, err := clobClient.GetApiKeys()
+	if err != nil {
+		t.Fatalf("GetApiKeys: %v", err)
+	}
+	var result []string
+	for _, key := range keys.APIKeys {
+		result = append(result, key.Key)
+	}
+	return result
+}
+
+func TestRederivesRejectedKey(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+	srv.AddApiKey(clobClient.GetSignerAddress(), types.ApiKeyCreds{Key: "key", Secret: "c2VjcmV0", Passphrase: "pass"})
+
+	other, err := client.NewClobClient(&client.ClientConfig{
+		Host:    srv.URL,
+		ChainID: types.ChainPolygon,
+		// A different wallet presenting the registered key
+		PrivateKey: otherKey,
+		APIKey:     &types.ApiKeyCreds{Key: "key", Secret: "c2VjcmV0", Passphrase: "pass"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The key belongs to another address, so the server answers 401 and the client
+	// creates its own key and retries
+	keys, err := other.GetApiKeys()
+	if err != nil {
+		t.Fatalf("GetApiKeys: %v", err)
+	}
+	if len(keys.APIKeys) != 1 || keys.APIKeys[0].Key == "key" {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+}
+
+func TestRotateApiKey(t *testing.T) {
+	srv := clobtest.NewServer()
+	t.Cleanup(srv.Close)
+	store := auth.NewMemoryCredentialStore()
+	clobClient := newStoreClient(t, srv, testKey, store)
+
+	old, err := clobClient.EnsureApiKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The old key is deleted only after the request signed with it has been answered
+	slow := slowApiKeysRequest(t, srv, clobClient, 200*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	rotation, err := clobClient.RotateApiKey(ctx)
+	if err != nil {
+		t.Fatalf("RotateApiKey: %v", err)
+	}
+	select {
+	case err := <-slow:
+		if err != nil {
+			t.Fatalf("in-flight request failed: %v", err)
+		}
+	default:
+		t.Fatal("the old key was revoked while a request signed with it was in flight")
+	}
+	if !rotation.Revoked || rotation.OldKey != old.Key || rotation.NewKey == old.Key || rotation.Nonce == 0 {
+		t.Fatalf("unexpected rotation: %+v", rotation)
+	}
+	// Had the in-flight request been rejected, the client would have created another key
+	if n := countRequests(srv, http.MethodPost, client.CreateApiKey); n != 2 {
+		t.Errorf("%d keys created, want 2", n)
+	}
+
+	// The rotated key is persisted with its nonce
+	stored, err := store.Load(auth.CredentialKey(types.ChainPolygon, common.HexToAddress(clobClient.GetSignerAddress())))
+	if err != nil || stored == nil || stored.Key != rotation.NewKey || stored.Nonce != rotation.Nonce {
+		t.Fatalf("stored credentials = %+v, %v", stored, err)
+	}
+	if keys := serverKeys(t, clobClient); len(keys) != 1 || keys[0] != rotation.NewKey {
+		t.Fatalf("server keys = %v, want only %s", keys, rotation.NewKey)
+	}
+
+	// After a restart a rejected request re-derives the rotated key at its nonce
+	restarted := newStoreClient(t, srv, testKey, store)
+	srv.AddFault(clobtest.Fault{Path: client.GetApiKeys, Status: http.StatusUnauthorized, Body: `{"error":"Unauthorized"}`, Times: 1})
+	if keys := serverKeys(t, restarted); len(keys) != 1 || keys[0] != rotation.NewKey {
+		t.Fatalf("server keys after re-deriving = %v, want only %s", keys, rotation.NewKey)
+	}
+	creds, _ := restarted.EnsureApiKey()
+	if creds.Key != rotation.NewKey || creds.Nonce != rotation.Nonce {
+		t.Fatalf("re-derived credentials = %+v", creds)
+	}
+}
+
+func TestRotateApiKeyTimeout(t *testing.T) {
+	srv := clobtest.NewServer()
+	t.Cleanup(srv.Close)
+	clobClient := newStoreClient(t, srv, testKey, nil)
+
+	old, err := clobClient.EnsureApiKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slow := slowApiKeysRequest(t, srv, clobClient, 300*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	rotation, err := clobClient.RotateApiKey(ctx)
+	if err == nil || rotation == nil || rotation.Revoked {
+		t.Fatalf("RotateApiKey = %+v, %v; want the old key left in place", rotation, err)
+	}
+	if err := <-slow; err != nil {
+		t.Fatalf("in-flight request failed: %v", err)
+	}
+	if keys := serverKeys(t, clobClient); len(keys) != 2 {
+		t.Fatalf("server keys = %v, want the old and new key", keys)
+	}
+
+	if err := clobClient.RevokeApiKey(old); err != nil {
+		t.Fatal(err)
+	}
+	if keys := serverKeys(t, clobClient); len(keys) != 1 || keys[0] != rotation.NewKey {
+		t.Fatalf("server keys after revoking = %v", keys)
+	}
+}
+
+func TestKeyManager(t *testing.T) {
+	srv := clobtest.NewServer()
+	t.Cleanup(srv.Close)
+	first := newStoreClient(t, srv, testKey, nil)
+	second := newStoreClient(t, srv, otherKey, nil)
+	for _, c := range []*client.ClobClient{first, second} {
+		if _, err := c.EnsureApiKey(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager := client.NewKeyManager(first, second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	rotations, err := manager.RotateAll(ctx)
+	if err != nil {
+		t.Fatalf("RotateAll: %v", err)
+	}
+	if len(rotations) != 2 {
+		t.Fatalf("rotations = %+v", rotations)
+	}
+
+	keys, err := manager.ListApiKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range manager.Clients() {
+		address := c.GetSignerAddress()
+		rotation := rotations[address]
+		if rotation == nil || !rotation.Revoked {
+			t.Errorf("%s: rotation = %+v", address, rotation)
+			continue
+		}
+		if list := keys[address]; list == nil || len(list.APIKeys) != 1 || list.APIKeys[0].Key != rotation.NewKey {
+			t.Errorf("%s: keys = %+v, want only %s", address, list, rotation.NewKey)
+		}
+	}
+
+	if err := manager.RevokeAll(); err != nil {
+		t.Fatalf("RevokeAll: %v", err)
+	}
+	if _, err := first.GetApiKeys(); err == nil {
+		t.Error("the client still has credentials after RevokeAll")
+	}
+}
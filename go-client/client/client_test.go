@@ -0,0 +1,52 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/clobtest"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+const testKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// newTestClient starts a fake CLOB with market 0xc0 (tokens 1 and 2, a book on 1) and
+// a client for it
+func newTestClient(t *testing.T) (*clobtest.Server, *client.ClobClient) {
+	t.Helper()
+
+	srv := clobtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddMarket(clobtest.Market{
+		ConditionID: "0xc0",
+		Question:    "Will it rain?",
+		Tokens:      []clobtest.Token{{TokenID: "1", Outcome: "Yes"}, {TokenID: "2", Outcome: "No"}},
+	})
+	srv.SetBook("1",
+		[]types.OrderSummary{{Price: "0.48", Size: "100"}},
+		[]types.OrderSummary{{Price: "0.52", Size: "100"}})
+
+	clobClient, err := client.NewClobClient(&client.ClientConfig{
+		Host:       srv.URL,
+		ChainID:    types.ChainPolygon,
+		PrivateKey: testKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, clobClient
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// ClobAPI is the public API of ClobClient. Depend on it instead of *ClobClient so code
+// can be tested against the clobtest fake server or a hand-written double.
+type ClobAPI interface {
+	Trader
+
+	// Public market data
+	GetOK() (interface{}, error)
+	GetServerTime() (int64, error)
+	GetSamplingSimplifiedMarkets(nextCursor string) (*types.PaginationPayload, error)
+	GetMarkets(nextCursor string) (*types.PaginationPayload, error)
+	GetMarket(conditionID string) (interface{}, error)
+	GetOrderBook(tokenID string) (*types.OrderBookSummary, error)
+	GetOrderBooks(params []types.BookParams) ([]types.OrderBookSummary, error)
+	GetTickSize(tokenID string) (types.TickSize, error)
+	GetNegRisk(tokenID string) (bool, error)
+	GetFeeRateBps(tokenID string) (int, error)
+	GetMidpoint(tokenID string) (interface{}, error)
+	GetMidpoints(params []types.BookParams) (interface{}, error)
+	GetPrice(tokenID string, side types.Side) (interface{}, error)
+	GetPrices(params []types.BookParams) (interface{}, error)
+	GetLastTradePrice(tokenID string) (interface{}, error)
+	GetLastTradesPrices(params []types.BookParams) (interface{}, error)
+
+	// API keys
+	CreateApiKey(nonce *uint64) (*types.ApiKeyCreds, error)
+	DeriveApiKey(nonce *uint64) (*types.ApiKeyCreds, error)
+	CreateOrDeriveApiKey(nonce *uint64) (*types.ApiKeyCreds, error)
+	EnsureApiKey() (*types.ApiKeyCreds, error)
+	GetApiKeys() (*types.ApiKeysResponse, error)
+	DeleteApiKey() (interface{}, error)
+	RotateApiKey(ctx context.Context) (*ApiKeyRotation, error)
+	RevokeApiKey(creds *types.ApiKeyCreds) error
+	GetClosedOnlyMode() (*types.BanStatus, error)
+
+	// Orders
+	CreateOrder(userOrder *types.UserOrder, options *types.CreateOrderOptions) (*types.SignedOrder, error)
+	PostOrder(order *types.SignedOrder, orderType types.OrderType) (*types.OrderResponse, error)
+
+	// Account
+	GetSignerAddress() string
+	GetSigner() auth.Signer
+	GetFunderAddress() string
+	GetSignatureType() types.SignatureType
+}
+
+var _ ClobAPI = (*ClobClient)(nil)
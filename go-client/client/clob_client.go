@@ -7,22 +7,32 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/HuakunShen/polymarket-kit/go-client/auth"
 	"github.com/HuakunShen/polymarket-kit/go-client/types"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // ClobClient represents a Polymarket CLOB client
 type ClobClient struct {
 	host          string
 	chainID       types.Chain
-	wallet        *auth.Wallet
+	signer        auth.Signer
 	creds         *types.ApiKeyCreds
-	builderConfig *auth.BuilderConfig
+	builderSigner auth.BuilderSigner
 	geoBlockToken string
 	useServerTime bool
 	httpClient    *http.Client
+	signatureType types.SignatureType
+	funderAddress string
+
+	credsMu         sync.RWMutex
+	credsInFlight   *sync.WaitGroup
+	refreshMu       sync.Mutex
+	credentialStore auth.CredentialStore
 }
 
 // ClientConfig represents configuration for the Clob client
@@ -35,6 +45,65 @@ type ClientConfig struct {
 	GeoBlockToken string
 	UseServerTime bool
 	Timeout       time.Duration
+
+	// Transport replaces the HTTP transport, e.g. to record or replay traffic
+	Transport http.RoundTripper
+
+	// SignatureType selects how orders are signed (EOA, Polymarket proxy or Gnosis Safe)
+	SignatureType types.SignatureType
+	// FunderAddress is the proxy wallet or Safe holding the funds; it becomes the order maker
+	// and is required for the POLY_PROXY and POLY_GNOSIS_SAFE signature types
+	FunderAddress string
+
+	// Alternatives to PrivateKey, checked in this order:
+	// Signer is used as is (e.g. a remote signer)
+	Signer auth.Signer
+	// Keystore loads the key from an encrypted keystore file
+	Keystore *auth.KeystoreConfig
+	// Mnemonic derives the key from a BIP-39 mnemonic
+	Mnemonic *auth.MnemonicConfig
+
+	// BuilderSigner attributes orders to a builder account; it takes precedence over
+	// BuilderConfig, e.g. an auth.RemoteBuilderSigner keeps the builder secret out of process
+	BuilderSigner auth.BuilderSigner
+
+	// CredentialStore caches API credentials across restarts. When APIKey is nil the
+	// client loads them from the store; EnsureApiKey derives and saves them if missing.
+	CredentialStore auth.CredentialStore
+
+	// TradingMode selects live or paper trading in NewTrader (default live)
+	TradingMode TradingMode
+	// Paper configures the simulated account used in paper mode
+	Paper *PaperConfig
+}
+
+// newSignerFromConfig picks the signer, keystore, mnemonic or private key, in that order
+func newSignerFromConfig(config *ClientConfig) (auth.Signer, error) {
+	if config.Signer != nil {
+		return config.Signer, nil
+	}
+
+	if config.Keystore != nil {
+		wallet, err := config.Keystore.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load wallet from keystore: %w", err)
+		}
+		return wallet.Signer(), nil
+	}
+
+	if config.Mnemonic != nil {
+		wallet, err := config.Mnemonic.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive wallet from mnemonic: %w", err)
+		}
+		return wallet.Signer(), nil
+	}
+
+	wallet, err := auth.NewWalletFromHex(config.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet from private key: %w", err)
+	}
+	return wallet.Signer(), nil
 }
 
 // NewClobClient creates a new CLOB client
@@ -45,10 +114,29 @@ func NewClobClient(config *ClientConfig) (*ClobClient, error) {
 		host = host[:len(host)-1]
 	}
 
-	// Create wallet from private key
-	wallet, err := auth.NewWalletFromHex(config.PrivateKey)
+	signer, err := newSignerFromConfig(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create wallet from private key: %w", err)
+		return nil, err
+	}
+
+	// Validate funder address for proxy and Safe signature types
+	funderAddress := config.FunderAddress
+	if config.SignatureType.RequiresFunder() && funderAddress == "" {
+		return nil, fmt.Errorf("funder address is required for signature type %d", config.SignatureType)
+	}
+	if funderAddress != "" {
+		if err := auth.ValidateAddress(funderAddress); err != nil {
+			return nil, fmt.Errorf("invalid funder address: %w", err)
+		}
+		funderAddress = common.HexToAddress(funderAddress).Hex()
+	}
+
+	builderSigner := config.BuilderSigner
+	if builderSigner == nil && config.BuilderConfig != nil {
+		if !config.BuilderConfig.IsValid() {
+			return nil, fmt.Errorf("invalid builder config: apiKey, secret and passphrase are required")
+		}
+		builderSigner = config.BuilderConfig
 	}
 
 	// Set default timeout
@@ -60,14 +148,28 @@ func NewClobClient(config *ClientConfig) (*ClobClient, error) {
 	client := &ClobClient{
 		host:          host,
 		chainID:       config.ChainID,
-		wallet:        wallet,
+		signer:        signer,
 		creds:         config.APIKey,
-		builderConfig: config.BuilderConfig,
+		builderSigner: builderSigner,
 		geoBlockToken: config.GeoBlockToken,
 		useServerTime: config.UseServerTime,
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: config.Transport,
 		},
+		signatureType:   config.SignatureType,
+		funderAddress:   funderAddress,
+		credentialStore: config.CredentialStore,
+		credsInFlight:   new(sync.WaitGroup),
+	}
+
+	// Reuse stored credentials instead of hitting the auth endpoint on every boot
+	if client.creds == nil && client.credentialStore != nil {
+		creds, err := client.credentialStore.Load(client.credentialKey())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load API credentials: %w", err)
+		}
+		client.creds = creds
 	}
 
 	return client, nil
@@ -136,12 +238,13 @@ func (c *ClobClient) GetTickSize(tokenID string) (types.TickSize, error) {
 	params := url.Values{}
 	params.Add("token_id", tokenID)
 
+	// The API returns the tick size as a number
 	var result struct {
-		MinimumTickSize types.TickSize `json:"minimum_tick_size"`
+		MinimumTickSize json.Number `json:"minimum_tick_size"`
 	}
 
 	err := c.getJSONWithParams(GetTickSize, params, &result)
-	return result.MinimumTickSize, err
+	return types.TickSize(result.MinimumTickSize.String()), err
 }
 
 // GetNegRisk gets negative risk flag for a token
@@ -224,7 +327,7 @@ func (c *ClobClient) CreateApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
 		timestamp = &serverTime
 	}
 
-	headers, err := auth.CreateL1Headers(c.wallet.GetPrivateKey(), c.chainID, nonce, timestamp)
+	headers, err := auth.CreateL1Headers(c.signer, c.chainID, nonce, timestamp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L1 headers: %w", err)
 	}
@@ -240,6 +343,9 @@ func (c *ClobClient) CreateApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
 		Secret:     apiKeyRaw.Secret,
 		Passphrase: apiKeyRaw.Passphrase,
 	}
+	if nonce != nil {
+		apiKey.Nonce = *nonce
+	}
 
 	return apiKey, nil
 }
@@ -258,7 +364,7 @@ func (c *ClobClient) DeriveApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
 		timestamp = &serverTime
 	}
 
-	headers, err := auth.CreateL1Headers(c.wallet.GetPrivateKey(), c.chainID, nonce, timestamp)
+	headers, err := auth.CreateL1Headers(c.signer, c.chainID, nonce, timestamp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L1 headers: %w", err)
 	}
@@ -274,64 +380,128 @@ func (c *ClobClient) DeriveApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
 		Secret:     apiKeyRaw.Secret,
 		Passphrase: apiKeyRaw.Passphrase,
 	}
+	if nonce != nil {
+		apiKey.Nonce = *nonce
+	}
 
 	return apiKey, nil
 }
 
-// GetApiKeys gets API keys
-func (c *ClobClient) GetApiKeys() (*types.ApiKeysResponse, error) {
-	if c.creds == nil {
-		return nil, fmt.Errorf("API credentials are required")
+// CreateOrDeriveApiKey creates an API key, or derives the existing one if it cannot be created
+func (c *ClobClient) CreateOrDeriveApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
+	apiKey, err := c.CreateApiKey(nonce)
+	if err == nil && apiKey.Key != "" {
+		return apiKey, nil
 	}
 
-	headerArgs := &types.L2HeaderArgs{
-		Method:      "GET",
-		RequestPath: GetApiKeys,
-	}
+	return c.DeriveApiKey(nonce)
+}
 
-	headers, err := c.createL2Headers(headerArgs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
+// EnsureApiKey returns the client's API credentials. Missing credentials are loaded from
+// the credential store, or created/derived and then saved to it.
+func (c *ClobClient) EnsureApiKey() (*types.ApiKeyCreds, error) {
+	if creds := c.getCreds(); creds != nil {
+		return creds, nil
 	}
 
-	var result types.ApiKeysResponse
-	err = c.getJSONWithHeaders(GetApiKeys, headers, &result)
-	return &result, err
-}
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
 
-// GetClosedOnlyMode gets closed only mode status
-func (c *ClobClient) GetClosedOnlyMode() (*types.BanStatus, error) {
-	if c.creds == nil {
-		return nil, fmt.Errorf("API credentials are required")
+	if creds := c.getCreds(); creds != nil {
+		return creds, nil
 	}
 
-	headerArgs := &types.L2HeaderArgs{
-		Method:      "GET",
-		RequestPath: ClosedOnly,
+	if c.credentialStore != nil {
+		creds, err := c.credentialStore.Load(c.credentialKey())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load API credentials: %w", err)
+		}
+		if creds != nil {
+			c.setCreds(creds)
+			return creds, nil
+		}
 	}
 
-	headers, err := c.createL2Headers(headerArgs)
+	creds, err := c.CreateOrDeriveApiKey(nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
+		return nil, fmt.Errorf("failed to create or derive API key: %w", err)
+	}
+	c.setCreds(creds)
+
+	if c.credentialStore != nil {
+		if err := c.credentialStore.Save(c.credentialKey(), creds); err != nil {
+			return creds, fmt.Errorf("failed to save API credentials: %w", err)
+		}
 	}
 
+	return creds, nil
+}
+
+// GetApiKeys gets API keys
+func (c *ClobClient) GetApiKeys() (*types.ApiKeysResponse, error) {
+	var result types.ApiKeysResponse
+	err := c.withApiKey(func(creds *types.ApiKeyCreds) error {
+		headers, err := c.createL2Headers(creds, &types.L2HeaderArgs{
+			Method:      "GET",
+			RequestPath: GetApiKeys,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+
+		return c.getJSONWithHeaders(GetApiKeys, headers, &result)
+	})
+	return &result, err
+}
+
+// GetClosedOnlyMode gets closed only mode status
+func (c *ClobClient) GetClosedOnlyMode() (*types.BanStatus, error) {
 	var result types.BanStatus
-	err = c.getJSONWithHeaders(ClosedOnly, headers, &result)
+	err := c.withApiKey(func(creds *types.ApiKeyCreds) error {
+		headers, err := c.createL2Headers(creds, &types.L2HeaderArgs{
+			Method:      "GET",
+			RequestPath: ClosedOnly,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+
+		return c.getJSONWithHeaders(ClosedOnly, headers, &result)
+	})
 	return &result, err
 }
 
 // DeleteApiKey deletes API key
 func (c *ClobClient) DeleteApiKey() (interface{}, error) {
-	if c.creds == nil {
+	creds := c.getCreds()
+	if creds == nil {
 		return nil, fmt.Errorf("API credentials are required")
 	}
 
+	result, err := c.deleteApiKey(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	// The deleted key must not be reused on the next boot
+	c.setCreds(nil)
+	if c.credentialStore != nil {
+		if err := c.credentialStore.Delete(c.credentialKey()); err != nil {
+			return result, fmt.Errorf("failed to delete stored API credentials: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// deleteApiKey deletes the key of the given credentials, which sign their own request
+func (c *ClobClient) deleteApiKey(creds *types.ApiKeyCreds) (interface{}, error) {
 	headerArgs := &types.L2HeaderArgs{
 		Method:      "DELETE",
 		RequestPath: DeleteApiKey,
 	}
 
-	headers, err := c.createL2Headers(headerArgs)
+	headers, err := c.createL2Headers(creds, headerArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
 	}
@@ -341,42 +511,25 @@ func (c *ClobClient) DeleteApiKey() (interface{}, error) {
 
 // GetOrder gets an order by ID
 func (c *ClobClient) GetOrder(orderID string) (*types.OpenOrder, error) {
-	if c.creds == nil {
-		return nil, fmt.Errorf("API credentials are required")
-	}
-
 	endpoint := GetOrder + orderID
-	headerArgs := &types.L2HeaderArgs{
-		Method:      "GET",
-		RequestPath: endpoint,
-	}
-
-	headers, err := c.createL2Headers(headerArgs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
-	}
 
 	var result types.OpenOrder
-	err = c.getJSONWithHeaders(endpoint, headers, &result)
+	err := c.withApiKey(func(creds *types.ApiKeyCreds) error {
+		headers, err := c.createL2Headers(creds, &types.L2HeaderArgs{
+			Method:      "GET",
+			RequestPath: endpoint,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+
+		return c.getJSONWithHeaders(endpoint, headers, &result)
+	})
 	return &result, err
 }
 
 // GetTrades gets trades
 func (c *ClobClient) GetTrades(params *types.TradeParams, onlyFirstPage bool, nextCursor string) ([]types.Trade, error) {
-	if c.creds == nil {
-		return nil, fmt.Errorf("API credentials are required")
-	}
-
-	headerArgs := &types.L2HeaderArgs{
-		Method:      "GET",
-		RequestPath: GetTrades,
-	}
-
-	headers, err := c.createL2Headers(headerArgs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
-	}
-
 	queryParams := url.Values{}
 	if nextCursor == "" {
 		nextCursor = "0"
@@ -409,12 +562,22 @@ func (c *ClobClient) GetTrades(params *types.TradeParams, onlyFirstPage bool, ne
 		NextCursor string        `json:"next_cursor"`
 	}
 
-	err = c.getJSONWithHeadersAndParams(GetTrades, headers, queryParams, &result)
+	err := c.withApiKey(func(creds *types.ApiKeyCreds) error {
+		headers, err := c.createL2Headers(creds, &types.L2HeaderArgs{
+			Method:      "GET",
+			RequestPath: GetTrades,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+
+		return c.getJSONWithHeadersAndParams(GetTrades, headers, queryParams, &result)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if onlyFirstPage || result.NextCursor == "-1" {
+	if onlyFirstPage || isEndCursor(result.NextCursor) {
 		return result.Data, nil
 	}
 
@@ -427,8 +590,112 @@ func (c *ClobClient) GetTrades(params *types.TradeParams, onlyFirstPage bool, ne
 	return append(result.Data, moreTrades...), nil
 }
 
+// GetOpenOrders gets open orders
+func (c *ClobClient) GetOpenOrders(params *types.OpenOrderParams, onlyFirstPage bool, nextCursor string) ([]types.OpenOrder, error) {
+	queryParams := url.Values{}
+	if nextCursor == "" {
+		nextCursor = "0"
+	}
+	queryParams.Add("next_cursor", nextCursor)
+
+	if params != nil {
+		if params.ID != nil {
+			queryParams.Add("id", *params.ID)
+		}
+		if params.Market != nil {
+			queryParams.Add("market", *params.Market)
+		}
+		if params.AssetID != nil {
+			queryParams.Add("asset_id", *params.AssetID)
+		}
+	}
+
+	var result struct {
+		Data       []types.OpenOrder `json:"data"`
+		NextCursor string            `json:"next_cursor"`
+	}
+
+	err := c.withApiKey(func(creds *types.ApiKeyCreds) error {
+		headers, err := c.createL2Headers(creds, &types.L2HeaderArgs{
+			Method:      "GET",
+			RequestPath: GetOpenOrders,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+
+		return c.getJSONWithHeadersAndParams(GetOpenOrders, headers, queryParams, &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if onlyFirstPage || isEndCursor(result.NextCursor) {
+		return result.Data, nil
+	}
+
+	moreOrders, err := c.GetOpenOrders(params, onlyFirstPage, result.NextCursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result.Data, moreOrders...), nil
+}
+
+// CancelOrder cancels an order by ID
+func (c *ClobClient) CancelOrder(orderID string) (*types.CancelOrdersResponse, error) {
+	return c.cancel(CancelOrder, &types.OrderPayload{OrderID: orderID})
+}
+
+// CancelOrders cancels multiple orders by ID
+func (c *ClobClient) CancelOrders(orderIDs []string) (*types.CancelOrdersResponse, error) {
+	return c.cancel(CancelOrders, orderIDs)
+}
+
+// CancelAll cancels all open orders
+func (c *ClobClient) CancelAll() (*types.CancelOrdersResponse, error) {
+	return c.cancel(CancelAll, nil)
+}
+
+// CancelMarketOrders cancels all open orders of a market or asset
+func (c *ClobClient) CancelMarketOrders(params *types.OrderMarketCancelParams) (*types.CancelOrdersResponse, error) {
+	return c.cancel(CancelMarketOrders, params)
+}
+
+// cancel sends a signed DELETE request to a cancel endpoint
+func (c *ClobClient) cancel(endpoint string, payload interface{}) (*types.CancelOrdersResponse, error) {
+	var body string
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request data: %w", err)
+		}
+		body = string(data)
+	}
+
+	var result types.CancelOrdersResponse
+	err := c.withApiKey(func(creds *types.ApiKeyCreds) error {
+		headers, err := c.createL2Headers(creds, &types.L2HeaderArgs{
+			Method:      "DELETE",
+			RequestPath: endpoint,
+			Body:        body,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+
+		return c.deleteJSONWithHeaders(endpoint, headers, payload, &result)
+	})
+	return &result, err
+}
+
 // Helper methods for HTTP requests
 
+// isEndCursor reports whether a pagination cursor marks the last page
+func isEndCursor(cursor string) bool {
+	return cursor == "" || cursor == EndCursor || cursor == "-1"
+}
+
 func (c *ClobClient) get(endpoint string) (interface{}, error) {
 	return c.getWithParams(endpoint, url.Values{})
 }
@@ -459,7 +726,7 @@ func (c *ClobClient) getWithParams(endpoint string, params url.Values) (interfac
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result interface{}
@@ -522,7 +789,7 @@ func (c *ClobClient) getJSONWithHeadersAndParams(endpoint string, headers interf
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return json.NewDecoder(resp.Body).Decode(result)
@@ -569,7 +836,54 @@ func (c *ClobClient) postJSONWithHeaders(endpoint string, headers interface{}, d
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+
+	return nil
+}
+
+func (c *ClobClient) deleteJSONWithHeaders(endpoint string, headers interface{}, data interface{}, result interface{}) error {
+	var bodyReader io.Reader
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request data: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequest("DELETE", c.host+endpoint, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Add headers
+	c.addHeadersToRequest(req, headers)
+
+	// Add geo block token if present
+	if c.geoBlockToken != "" {
+		q := req.URL.Query()
+		q.Add("geo_block_token", c.geoBlockToken)
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	if result != nil {
@@ -603,7 +917,7 @@ func (c *ClobClient) deleteWithHeaders(endpoint string, headers interface{}) (in
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result interface{}
@@ -615,7 +929,97 @@ func (c *ClobClient) deleteWithHeaders(endpoint string, headers interface{}) (in
 	return result, nil
 }
 
-func (c *ClobClient) createL2Headers(args *types.L2HeaderArgs) (interface{}, error) {
+// withApiKey runs an L2 request with the current credentials. If the server rejects them
+// with 401 the key is re-derived once and the request is retried with fresh headers.
+func (c *ClobClient) withApiKey(do func(creds *types.ApiKeyCreds) error) error {
+	creds, release := c.acquireCreds()
+	if creds == nil {
+		return fmt.Errorf("API credentials are required")
+	}
+
+	err := do(creds)
+	release()
+	if !IsUnauthorized(err) {
+		return err
+	}
+
+	if refreshErr := c.refreshApiKey(creds); refreshErr != nil {
+		return fmt.Errorf("%w (re-deriving API key failed: %v)", err, refreshErr)
+	}
+
+	fresh, release := c.acquireCreds()
+	defer release()
+	if fresh == nil {
+		return fmt.Errorf("API credentials are required")
+	}
+
+	return do(fresh)
+}
+
+// refreshApiKey replaces rejected credentials, deriving at most once for concurrent callers
+func (c *ClobClient) refreshApiKey(rejected *types.ApiKeyCreds) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// Another request already replaced the rejected key
+	if current := c.getCreds(); current != nil && current.Key != rejected.Key {
+		return nil
+	}
+
+	// The stored nonce leads back to a rotated key after a restart instead of nonce 0
+	nonce := rejected.Nonce
+	creds, err := c.CreateOrDeriveApiKey(&nonce)
+	if err != nil {
+		return err
+	}
+	c.setCreds(creds)
+
+	// A failed save only costs a derivation on the next boot, the new key is usable now
+	if c.credentialStore != nil {
+		_ = c.credentialStore.Save(c.credentialKey(), creds)
+	}
+
+	return nil
+}
+
+func (c *ClobClient) getCreds() *types.ApiKeyCreds {
+	c.credsMu.RLock()
+	defer c.credsMu.RUnlock()
+	return c.creds
+}
+
+// acquireCreds returns the current credentials and marks a request signed with them as
+// in flight until release is called
+func (c *ClobClient) acquireCreds() (creds *types.ApiKeyCreds, release func()) {
+	c.credsMu.RLock()
+	defer c.credsMu.RUnlock()
+
+	if c.creds == nil {
+		return nil, func() {}
+	}
+
+	inFlight := c.credsInFlight
+	inFlight.Add(1)
+	return c.creds, inFlight.Done
+}
+
+// setCreds switches to new credentials and returns the in-flight tracker of the previous ones
+func (c *ClobClient) setCreds(creds *types.ApiKeyCreds) *sync.WaitGroup {
+	c.credsMu.Lock()
+	defer c.credsMu.Unlock()
+
+	previous := c.credsInFlight
+	c.creds = creds
+	c.credsInFlight = new(sync.WaitGroup)
+	return previous
+}
+
+// credentialKey identifies this client's credentials in the credential store
+func (c *ClobClient) credentialKey() string {
+	return auth.CredentialKey(c.chainID, c.signer.Address())
+}
+
+func (c *ClobClient) createL2Headers(creds *types.ApiKeyCreds, args *types.L2HeaderArgs) (interface{}, error) {
 	var timestamp *int64
 	if c.useServerTime {
 		serverTime, err := c.GetServerTime()
@@ -625,7 +1029,34 @@ func (c *ClobClient) createL2Headers(args *types.L2HeaderArgs) (interface{}, err
 		timestamp = &serverTime
 	}
 
-	return auth.CreateL2Headers(c.wallet.GetPrivateKey(), c.creds, args, timestamp)
+	return auth.CreateL2Headers(c.signer, creds, args, timestamp)
+}
+
+// createL2HeadersWithBuilder adds the POLY_BUILDER_* headers to the L2 headers when a
+// builder signer is configured. Both are signed over the same timestamp.
+func (c *ClobClient) createL2HeadersWithBuilder(creds *types.ApiKeyCreds, args *types.L2HeaderArgs) (interface{}, error) {
+	headers, err := c.createL2Headers(creds, args)
+	if err != nil || c.builderSigner == nil {
+		return headers, err
+	}
+
+	l2Headers := headers.(*types.L2PolyHeader)
+	timestamp, err := strconv.ParseInt(l2Headers.POLYTimestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid L2 timestamp: %w", err)
+	}
+
+	var body *string
+	if args.Body != "" {
+		body = &args.Body
+	}
+
+	builderHeaders, err := c.builderSigner.BuilderHeaders(args.Method, args.RequestPath, body, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create builder headers: %w", err)
+	}
+
+	return auth.InjectBuilderHeaders(l2Headers, builderHeaders), nil
 }
 
 func (c *ClobClient) addHeadersToRequest(req *http.Request, headers interface{}) {
@@ -1,5 +1,8 @@
 package client
 
+// EndCursor is the next_cursor value returned with the last page ("-1" in base64)
+const EndCursor = "LTE="
+
 // API endpoint constants
 const (
 	CancelAll                    = "/cancel-all"
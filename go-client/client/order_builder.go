@@ -0,0 +1,336 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+const (
+	// zeroAddress is used as the taker for public orders
+	zeroAddress = "0x0000000000000000000000000000000000000000"
+
+	// collateralDecimals is the number of decimals of USDC and conditional tokens
+	collateralDecimals = 6
+)
+
+// roundingConfig maps a tick size to the decimals used for price, size and amount
+var roundingConfig = map[types.TickSize]types.RoundConfig{
+	types.TickSize01:    {Price: 1, Size: 2, Amount: 3},
+	types.TickSize001:   {Price: 2, Size: 2, Amount: 4},
+	types.TickSize0001:  {Price: 3, Size: 2, Amount: 5},
+	types.TickSize00001: {Price: 4, Size: 2, Amount: 6},
+}
+
+// orderPayload is the wire format of a signed order
+type orderPayload struct {
+	Salt          int64               `json:"salt"`
+	Maker         string              `json:"maker"`
+	Signer        string              `json:"signer"`
+	Taker         string              `json:"taker"`
+	TokenID       string              `json:"tokenId"`
+	MakerAmount   string              `json:"makerAmount"`
+	TakerAmount   string              `json:"takerAmount"`
+	Side          types.Side          `json:"side"`
+	Expiration    string              `json:"expiration"`
+	Nonce         string              `json:"nonce"`
+	FeeRateBps    string              `json:"feeRateBps"`
+	SignatureType types.SignatureType `json:"signatureType"`
+	Signature     string              `json:"signature"`
+}
+
+// postOrderRequest is the body sent to the order endpoint
+type postOrderRequest struct {
+	Order     orderPayload    `json:"order"`
+	Owner     string          `json:"owner"`
+	OrderType types.OrderType `json:"orderType"`
+}
+
+// GetSignerAddress returns the address of the key signing orders and auth headers
+func (c *ClobClient) GetSignerAddress() string {
+	return c.signer.Address().Hex()
+}
+
+// GetSigner returns the signer used for auth headers and orders
+func (c *ClobClient) GetSigner() auth.Signer {
+	return c.signer
+}
+
+// GetFunderAddress returns the address that funds orders (the order maker)
+func (c *ClobClient) GetFunderAddress() string {
+	if c.funderAddress != "" {
+		return c.funderAddress
+	}
+	return c.GetSignerAddress()
+}
+
+// GetSignatureType returns the signature type used for orders
+func (c *ClobClient) GetSignatureType() types.SignatureType {
+	return c.signatureType
+}
+
+// CreateOrder builds and signs a limit order
+func (c *ClobClient) CreateOrder(userOrder *types.UserOrder, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	if userOrder == nil {
+		return nil, fmt.Errorf("order is required")
+	}
+
+	tickSize, negRisk, err := c.resolveOrderOptions(userOrder.TokenID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isPriceInRange(userOrder.Price, tickSize) {
+		return nil, fmt.Errorf("invalid price (%v), min: %s - max: %v", userOrder.Price, tickSize, 1-parseTickSize(tickSize))
+	}
+
+	round := roundingConfig[tickSize]
+	makerAmount, takerAmount, err := getOrderRawAmounts(userOrder.Side, userOrder.Size, userOrder.Price, round)
+	if err != nil {
+		return nil, err
+	}
+
+	feeRateBps, err := c.resolveFeeRateBps(userOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := GetContractConfig(c.chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &types.SignedOrder{
+		Salt:          strconv.FormatInt(generateSalt(), 10),
+		Maker:         c.GetFunderAddress(),
+		Signer:        c.GetSignerAddress(),
+		Taker:         zeroAddress,
+		TokenID:       userOrder.TokenID,
+		MakerAmount:   toTokenDecimals(makerAmount),
+		TakerAmount:   toTokenDecimals(takerAmount),
+		Expiration:    "0",
+		Nonce:         "0",
+		FeeRateBps:    strconv.Itoa(feeRateBps),
+		Side:          userOrder.Side,
+		SignatureType: c.signatureType,
+	}
+
+	if userOrder.Taker != "" {
+		order.Taker = userOrder.Taker
+	}
+	if userOrder.Expiration != nil {
+		order.Expiration = strconv.Itoa(*userOrder.Expiration)
+	}
+	if userOrder.Nonce != nil {
+		order.Nonce = strconv.Itoa(*userOrder.Nonce)
+	}
+
+	signature, err := auth.BuildOrderSignature(c.signer, order, int64(c.chainID), contracts.ExchangeAddress(negRisk))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign order: %w", err)
+	}
+	order.Signature = signature
+
+	return order, nil
+}
+
+// PostOrder posts a signed order
+func (c *ClobClient) PostOrder(order *types.SignedOrder, orderType types.OrderType) (*types.OrderResponse, error) {
+	var result types.OrderResponse
+	err := c.withApiKey(func(creds *types.ApiKeyCreds) error {
+		// The owner is part of the signed body, so it is rebuilt for each key
+		payload, err := newPostOrderRequest(order, creds.Key, orderType)
+		if err != nil {
+			return err
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal order: %w", err)
+		}
+
+		headers, err := c.createL2HeadersWithBuilder(creds, &types.L2HeaderArgs{
+			Method:      "POST",
+			RequestPath: PostOrder,
+			Body:        string(body),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+
+		return c.postJSONWithHeaders(PostOrder, headers, payload, &result)
+	})
+	return &result, err
+}
+
+// CreateAndPostOrder builds, signs and posts a limit order
+func (c *ClobClient) CreateAndPostOrder(userOrder *types.UserOrder, options *types.CreateOrderOptions, orderType types.OrderType) (*types.OrderResponse, error) {
+	order, err := c.CreateOrder(userOrder, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PostOrder(order, orderType)
+}
+
+// resolveOrderOptions fills in tick size and neg risk from the API when not provided
+func (c *ClobClient) resolveOrderOptions(tokenID string, options *types.CreateOrderOptions) (types.TickSize, bool, error) {
+	var tickSize types.TickSize
+	var negRisk *bool
+	if options != nil {
+		tickSize = options.TickSize
+		negRisk = options.NegRisk
+	}
+
+	if tickSize == "" {
+		minTickSize, err := c.GetTickSize(tokenID)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to get tick size: %w", err)
+		}
+		tickSize = minTickSize
+	}
+
+	if _, ok := roundingConfig[tickSize]; !ok {
+		return "", false, fmt.Errorf("invalid tick size: %s", tickSize)
+	}
+
+	if negRisk == nil {
+		value, err := c.GetNegRisk(tokenID)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to get neg risk: %w", err)
+		}
+		negRisk = &value
+	}
+
+	return tickSize, *negRisk, nil
+}
+
+// resolveFeeRateBps returns the order's fee rate, fetching the token's rate from the API
+// when not provided
+func (c *ClobClient) resolveFeeRateBps(userOrder *types.UserOrder) (int, error) {
+	if userOrder.FeeRateBps != nil {
+		return *userOrder.FeeRateBps, nil
+	}
+
+	rate, err := c.GetFeeRateBps(userOrder.TokenID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fee rate: %w", err)
+	}
+	return rate, nil
+}
+
+// newPostOrderRequest converts a signed order into its wire format
+func newPostOrderRequest(order *types.SignedOrder, owner string, orderType types.OrderType) (*postOrderRequest, error) {
+	if order == nil || order.MakerAmount == nil || order.TakerAmount == nil {
+		return nil, fmt.Errorf("a signed order with amounts is required")
+	}
+
+	salt, err := strconv.ParseInt(order.Salt, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+
+	if orderType == "" {
+		orderType = types.OrderTypeGTC
+	}
+
+	return &postOrderRequest{
+		Order: orderPayload{
+			Salt:          salt,
+			Maker:         order.Maker,
+			Signer:        order.Signer,
+			Taker:         order.Taker,
+			TokenID:       order.TokenID,
+			MakerAmount:   order.MakerAmount.String(),
+			TakerAmount:   order.TakerAmount.String(),
+			Side:          order.Side,
+			Expiration:    order.Expiration,
+			Nonce:         order.Nonce,
+			FeeRateBps:    order.FeeRateBps,
+			SignatureType: order.SignatureType,
+			Signature:     order.Signature,
+		},
+		Owner:     owner,
+		OrderType: orderType,
+	}, nil
+}
+
+// getOrderRawAmounts computes maker and taker amounts before decimal conversion
+func getOrderRawAmounts(side types.Side, size float64, price float64, round types.RoundConfig) (float64, float64, error) {
+	rawPrice := roundNormal(price, int(round.Price))
+
+	switch side {
+	case types.SideBuy:
+		rawTakerAmount := roundDown(size, int(round.Size))
+		rawMakerAmount := adjustAmount(rawTakerAmount*rawPrice, int(round.Amount))
+		return rawMakerAmount, rawTakerAmount, nil
+	case types.SideSell:
+		rawMakerAmount := roundDown(size, int(round.Size))
+		rawTakerAmount := adjustAmount(rawMakerAmount*rawPrice, int(round.Amount))
+		return rawMakerAmount, rawTakerAmount, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid side: %s", side)
+	}
+}
+
+// adjustAmount limits an amount to the allowed number of decimals
+func adjustAmount(amount float64, decimals int) float64 {
+	if decimalPlaces(amount) > decimals {
+		amount = roundUp(amount, decimals+4)
+		if decimalPlaces(amount) > decimals {
+			amount = roundDown(amount, decimals)
+		}
+	}
+	return amount
+}
+
+func roundNormal(value float64, decimals int) float64 {
+	factor := math.Pow10(decimals)
+	return math.Round(value*factor) / factor
+}
+
+func roundDown(value float64, decimals int) float64 {
+	factor := math.Pow10(decimals)
+	return math.Floor(value*factor) / factor
+}
+
+func roundUp(value float64, decimals int) float64 {
+	factor := math.Pow10(decimals)
+	return math.Ceil(value*factor) / factor
+}
+
+func decimalPlaces(value float64) int {
+	formatted := strconv.FormatFloat(value, 'f', -1, 64)
+	for i := 0; i < len(formatted); i++ {
+		if formatted[i] == '.' {
+			return len(formatted) - i - 1
+		}
+	}
+	return 0
+}
+
+// toTokenDecimals converts an amount to its 6 decimal integer representation
+func toTokenDecimals(amount float64) *big.Int {
+	return big.NewInt(int64(math.Round(amount * math.Pow10(collateralDecimals))))
+}
+
+func parseTickSize(tickSize types.TickSize) float64 {
+	value, _ := strconv.ParseFloat(string(tickSize), 64)
+	return value
+}
+
+func isPriceInRange(price float64, tickSize types.TickSize) bool {
+	tick := parseTickSize(tickSize)
+	return price >= tick && price <= 1-tick
+}
+
+func generateSalt() int64 {
+	return rand.Int63n(time.Now().UnixMilli())
+}
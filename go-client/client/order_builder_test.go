@@ -0,0 +1,54 @@
+package client_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/clobtest"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+func TestCreateOrderFeeRate(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+	srv.AddMarket(clobtest.Market{
+		ConditionID: "0xfee",
+		Tokens:      []clobtest.Token{{TokenID: "10", Outcome: "Yes"}, {TokenID: "11", Outcome: "No"}},
+		FeeRateBps:  200,
+	})
+
+	// Without a fee rate the token's rate is fetched and signed into the order
+	order, err := clobClient.CreateOrder(&types.UserOrder{TokenID: "10", Price: 0.5, Size: 10, Side: types.SideBuy}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.FeeRateBps != "200" {
+		t.Errorf("FeeRateBps = %s, want 200", order.FeeRateBps)
+	}
+	contracts, _ := client.GetContractConfig(types.ChainPolygon)
+	hash, err := auth.BuildOrderHash(order, int64(types.ChainPolygon), contracts.ExchangeAddress(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer, err := auth.RecoverAddress(hash, order.Signature); err != nil || signer.Hex() != clobClient.GetSignerAddress() {
+		t.Errorf("signature recovers to %s, %v", signer.Hex(), err)
+	}
+
+	// An explicit rate, zero included, is used as is
+	zero := 0
+	order, err = clobClient.CreateOrder(&types.UserOrder{TokenID: "10", Price: 0.5, Size: 10, Side: types.SideBuy, FeeRateBps: &zero}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.FeeRateBps != "0" {
+		t.Errorf("FeeRateBps = %s, want 0", order.FeeRateBps)
+	}
+
+	negRisk := false
+	_, err = clobClient.CreateOrder(&types.UserOrder{TokenID: "99", Price: 0.5, Size: 10, Side: types.SideBuy},
+		&types.CreateOrderOptions{TickSize: types.TickSize("0.01"), NegRisk: &negRisk})
+	if err == nil || !strings.Contains(err.Error(), "fee rate") {
+		t.Errorf("unknown token err = %v, want a fee rate error", err)
+	}
+}
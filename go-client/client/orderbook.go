@@ -0,0 +1,533 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// PriceLevel is one price level of an order book
+type PriceLevel struct {
+	Price float64
+	Size  float64
+}
+
+// OrderBookConfig configures OrderBooks
+type OrderBookConfig struct {
+	// VerifyHash compares the hash of the local book with the hash of every book and
+	// price_change message and resyncs on mismatch. The best bid/ask carried by price
+	// changes are always checked.
+	//
+	// Experimental: OrderBookHash follows py-clob-client but has not been checked against
+	// hashes recorded from the live CLOB, so a mismatch may mean a formula difference
+	// rather than a bad book. Books seeded from the websocket lack tick size, min order
+	// size and neg risk, so their first check always resyncs from REST.
+	VerifyHash bool
+
+	// OnUpdate is called after a book changed
+	OnUpdate func(book *OrderBook)
+
+	// OnResync is called when a book is resynced from REST, with the reason
+	OnResync func(assetID string, reason error)
+
+	// OnError receives failed resyncs
+	OnError func(err error)
+}
+
+// OrderBooks maintains local L2 order books from market channel messages. Wire OnBook,
+// OnPriceChange and OnTickSizeChange into WebSocketCallbacks. Books that fall out of sync
+// (gap, best bid/ask or hash mismatch) are reloaded with GetOrderBook in the background;
+// price changes received meanwhile are replayed on top of the snapshot.
+type OrderBooks struct {
+	source ClobAPI
+	config *OrderBookConfig
+
+	mu    sync.RWMutex
+	books map[string]*OrderBook
+}
+
+// OrderBook is the local L2 book of one asset. All methods are safe for concurrent use.
+type OrderBook struct {
+	assetID string
+
+	mu           sync.RWMutex
+	market       string
+	timestamp    int64
+	hash         string
+	tickSize     string
+	minOrderSize string
+	negRisk      bool
+	bids         map[float64]*bookLevel
+	asks         map[float64]*bookLevel
+	synced       bool
+	// pending buffers price changes received while a resync is in flight
+	resyncing bool
+	pending   []*types.PriceChangeMessage
+}
+
+// bookLevel keeps the wire strings so snapshots (and their hashes) match the server
+type bookLevel struct {
+	price string
+	size  string
+	value float64
+}
+
+// NewOrderBooks creates an order book manager that resyncs through source
+func NewOrderBooks(source ClobAPI, config *OrderBookConfig) *OrderBooks {
+	if config == nil {
+		config = &OrderBookConfig{}
+	}
+	return &OrderBooks{
+		source: source,
+		config: config,
+		books:  make(map[string]*OrderBook),
+	}
+}
+
+// Book returns the local book of an asset
+func (b *OrderBooks) Book(assetID string) (*OrderBook, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	book, ok := b.books[assetID]
+	return book, ok
+}
+
+// AssetIDs returns the assets with a local book
+func (b *OrderBooks) AssetIDs() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ids := make([]string, 0, len(b.books))
+	for id := range b.books {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Remove drops the local book of an asset, e.g. after unsubscribing
+func (b *OrderBooks) Remove(assetID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.books, assetID)
+}
+
+// OnBook seeds or replaces a book from a snapshot; it can be used as WebSocketCallbacks.OnBook
+func (b *OrderBooks) OnBook(msg *types.BookMessage) {
+	book := b.book(msg.AssetID)
+
+	book.mu.Lock()
+	timestamp, _ := strconv.ParseInt(msg.Timestamp, 10, 64)
+	if book.synced && timestamp < book.timestamp {
+		book.mu.Unlock()
+		return
+	}
+	book.load(msg.Market, timestamp, msg.Hash, msg.Bids, msg.Asks)
+	err := b.verify(book, msg.Hash)
+	book.mu.Unlock()
+
+	if err != nil {
+		b.resync(book, err)
+		return
+	}
+	b.updated(book)
+}
+
+// OnPriceChange applies level updates; it can be used as WebSocketCallbacks.OnPriceChange
+func (b *OrderBooks) OnPriceChange(msg *types.PriceChangeMessage) {
+	// One message can touch several assets; apply each asset's changes together
+	byAsset := make(map[string][]types.PriceChange)
+	var order []string
+	for _, change := range msg.PriceChanges {
+		if _, ok := byAsset[change.AssetID]; !ok {
+			order = append(order, change.AssetID)
+		}
+		byAsset[change.AssetID] = append(byAsset[change.AssetID], change)
+	}
+
+	for _, assetID := range order {
+		b.applyChanges(b.book(assetID), msg.Market, msg.Timestamp, byAsset[assetID])
+	}
+}
+
+// OnTickSizeChange records the new tick size; it can be used as
+// WebSocketCallbacks.OnTickSizeChange
+func (b *OrderBooks) OnTickSizeChange(msg *types.TickSizeChangeMessage) {
+	book, ok := b.Book(msg.AssetID)
+	if !ok {
+		return
+	}
+
+	book.mu.Lock()
+	book.tickSize = msg.NewTickSize
+	book.mu.Unlock()
+}
+
+// Resync reloads a book from REST immediately
+func (b *OrderBooks) Resync(assetID string) error {
+	book := b.book(assetID)
+
+	book.mu.Lock()
+	book.resyncing = true
+	book.mu.Unlock()
+
+	return b.reload(book)
+}
+
+func (b *OrderBooks) applyChanges(book *OrderBook, market string, ts string, changes []types.PriceChange) {
+	timestamp, _ := strconv.ParseInt(ts, 10, 64)
+
+	book.mu.Lock()
+	if book.resyncing {
+		book.pending = append(book.pending, &types.PriceChangeMessage{
+			EventType:    types.EventTypePriceChange,
+			Market:       market,
+			PriceChanges: changes,
+			Timestamp:    ts,
+		})
+		book.mu.Unlock()
+		return
+	}
+	if !book.synced {
+		book.mu.Unlock()
+		b.resync(book, fmt.Errorf("price change before book snapshot"))
+		return
+	}
+	if timestamp < book.timestamp {
+		book.mu.Unlock()
+		return
+	}
+
+	err := book.apply(timestamp, changes)
+	if err == nil {
+		err = b.verify(book, changes[len(changes)-1].Hash)
+	}
+	book.mu.Unlock()
+
+	if err != nil {
+		b.resync(book, err)
+		return
+	}
+	b.updated(book)
+}
+
+// verify compares the book hash when enabled. The caller must hold book.mu.
+func (b *OrderBooks) verify(book *OrderBook, hash string) error {
+	if !b.config.VerifyHash || hash == "" {
+		return nil
+	}
+	if local := types.OrderBookHash(book.snapshot()); local != hash {
+		return fmt.Errorf("hash mismatch: local %s, server %s", local, hash)
+	}
+	return nil
+}
+
+// resync marks the book out of sync and reloads it in the background, once at a time
+func (b *OrderBooks) resync(book *OrderBook, reason error) {
+	book.mu.Lock()
+	if book.resyncing {
+		book.mu.Unlock()
+		return
+	}
+	book.resyncing = true
+	book.synced = false
+	book.pending = nil
+	book.mu.Unlock()
+
+	if b.config.OnResync != nil {
+		b.config.OnResync(book.assetID, reason)
+	}
+
+	go func() {
+		if err := b.reload(book); err != nil && b.config.OnError != nil {
+			b.config.OnError(err)
+		}
+	}()
+}
+
+// reload loads a REST snapshot and replays the price changes buffered meanwhile
+func (b *OrderBooks) reload(book *OrderBook) error {
+	summary, err := b.source.GetOrderBook(book.assetID)
+	if err != nil {
+		book.mu.Lock()
+		book.resyncing = false
+		book.pending = nil
+		book.mu.Unlock()
+		return fmt.Errorf("failed to resync order book %s: %w", book.assetID, err)
+	}
+
+	book.mu.Lock()
+	timestamp, _ := strconv.ParseInt(summary.Timestamp, 10, 64)
+	book.load(summary.Market, timestamp, summary.Hash, summary.Bids, summary.Asks)
+	if summary.TickSize != "" {
+		book.tickSize = summary.TickSize
+	}
+	book.minOrderSize = summary.MinOrderSize
+	book.negRisk = summary.NegRisk
+
+	var replayErr error
+	for _, msg := range book.pending {
+		pendingTimestamp, _ := strconv.ParseInt(msg.Timestamp, 10, 64)
+		if pendingTimestamp < book.timestamp {
+			continue
+		}
+		if replayErr = book.apply(pendingTimestamp, msg.PriceChanges); replayErr != nil {
+			break
+		}
+	}
+	if replayErr != nil {
+		// The snapshot itself is good; undo the deltas applied before the one that
+		// did not fit it
+		book.load(summary.Market, timestamp, summary.Hash, summary.Bids, summary.Asks)
+	}
+	book.pending = nil
+	book.resyncing = false
+	book.mu.Unlock()
+
+	b.updated(book)
+	return nil
+}
+
+func (b *OrderBooks) book(assetID string) *OrderBook {
+	b.mu.RLock()
+	book, ok := b.books[assetID]
+	b.mu.RUnlock()
+	if ok {
+		return book
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if book, ok := b.books[assetID]; ok {
+		return book
+	}
+	book = &OrderBook{
+		assetID: assetID,
+		bids:    make(map[float64]*bookLevel),
+		asks:    make(map[float64]*bookLevel),
+	}
+	b.books[assetID] = book
+	return book
+}
+
+func (b *OrderBooks) updated(book *OrderBook) {
+	if b.config.OnUpdate != nil {
+		b.config.OnUpdate(book)
+	}
+}
+
+// load replaces the levels. The caller must hold book.mu.
+func (book *OrderBook) load(market string, timestamp int64, hash string, bids []types.OrderSummary, asks []types.OrderSummary) {
+	book.market = market
+	book.timestamp = timestamp
+	book.hash = hash
+	book.bids = loadLevels(bids)
+	book.asks = loadLevels(asks)
+	book.synced = true
+}
+
+// apply applies level updates and checks the best bid/ask they report.
+// The caller must hold book.mu.
+func (book *OrderBook) apply(timestamp int64, changes []types.PriceChange) error {
+	for _, change := range changes {
+		price, err := strconv.ParseFloat(change.Price, 64)
+		if err != nil {
+			return fmt.Errorf("invalid price %q", change.Price)
+		}
+		size, err := strconv.ParseFloat(change.Size, 64)
+		if err != nil {
+			return fmt.Errorf("invalid size %q", change.Size)
+		}
+
+		levels := book.bids
+		if change.Side == types.SideSell {
+			levels = book.asks
+		}
+		if size == 0 {
+			delete(levels, price)
+		} else {
+			levels[price] = &bookLevel{price: change.Price, size: change.Size, value: size}
+		}
+		book.hash = change.Hash
+	}
+	book.timestamp = timestamp
+
+	last := changes[len(changes)-1]
+	if err := checkBest("bid", last.BestBid, book.bids, true); err != nil {
+		return err
+	}
+	return checkBest("ask", last.BestAsk, book.asks, false)
+}
+
+// AssetID returns the asset of the book
+func (book *OrderBook) AssetID() string {
+	return book.assetID
+}
+
+// Synced reports whether the book is seeded and not waiting for a resync
+func (book *OrderBook) Synced() bool {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	return book.synced && !book.resyncing
+}
+
+// Timestamp returns the time of the last applied update in milliseconds
+func (book *OrderBook) Timestamp() int64 {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	return book.timestamp
+}
+
+// BestBid returns the highest bid
+func (book *OrderBook) BestBid() (PriceLevel, bool) {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	return bestLevel(book.bids, true)
+}
+
+// BestAsk returns the lowest ask
+func (book *OrderBook) BestAsk() (PriceLevel, bool) {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	return bestLevel(book.asks, false)
+}
+
+// Midpoint returns the midpoint of the best bid and ask
+func (book *OrderBook) Midpoint() (float64, bool) {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	bid, hasBid := bestLevel(book.bids, true)
+	ask, hasAsk := bestLevel(book.asks, false)
+	if !hasBid || !hasAsk {
+		return 0, false
+	}
+	return (bid.Price + ask.Price) / 2, true
+}
+
+// Depth returns up to n levels of one side, best first (n <= 0 returns all)
+func (book *OrderBook) Depth(side types.Side, n int) []PriceLevel {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	levels := book.bids
+	if side == types.SideSell {
+		levels = book.asks
+	}
+
+	prices := sortedPrices(levels, side == types.SideBuy)
+	if n > 0 && len(prices) > n {
+		prices = prices[:n]
+	}
+
+	result := make([]PriceLevel, len(prices))
+	for i, price := range prices {
+		result[i] = PriceLevel{Price: price, Size: levels[price].value}
+	}
+	return result
+}
+
+// Snapshot returns the book in the REST format: bids ascending and asks descending, so
+// the best price of each side is last
+func (book *OrderBook) Snapshot() *types.OrderBookSummary {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	return book.snapshot()
+}
+
+// snapshot builds the summary. The caller must hold book.mu.
+func (book *OrderBook) snapshot() *types.OrderBookSummary {
+	return &types.OrderBookSummary{
+		Market:       book.market,
+		AssetID:      book.assetID,
+		Timestamp:    strconv.FormatInt(book.timestamp, 10),
+		Bids:         summaryLevels(book.bids, false),
+		Asks:         summaryLevels(book.asks, true),
+		MinOrderSize: book.minOrderSize,
+		TickSize:     book.tickSize,
+		NegRisk:      book.negRisk,
+		Hash:         book.hash,
+	}
+}
+
+func loadLevels(summaries []types.OrderSummary) map[float64]*bookLevel {
+	levels := make(map[float64]*bookLevel, len(summaries))
+	for _, level := range summaries {
+		price, err1 := strconv.ParseFloat(level.Price, 64)
+		size, err2 := strconv.ParseFloat(level.Size, 64)
+		if err1 != nil || err2 != nil || size == 0 {
+			continue
+		}
+		levels[price] = &bookLevel{price: level.Price, size: level.Size, value: size}
+	}
+	return levels
+}
+
+func summaryLevels(levels map[float64]*bookLevel, descending bool) []types.OrderSummary {
+	prices := sortedPrices(levels, descending)
+	result := make([]types.OrderSummary, len(prices))
+	for i, price := range prices {
+		result[i] = types.OrderSummary{Price: levels[price].price, Size: levels[price].size}
+	}
+	return result
+}
+
+func sortedPrices(levels map[float64]*bookLevel, descending bool) []float64 {
+	prices := make([]float64, 0, len(levels))
+	for price := range levels {
+		prices = append(prices, price)
+	}
+	if descending {
+		sort.Sort(sort.Reverse(sort.Float64Slice(prices)))
+	} else {
+		sort.Float64s(prices)
+	}
+	return prices
+}
+
+func bestLevel(levels map[float64]*bookLevel, highest bool) (PriceLevel, bool) {
+	var best PriceLevel
+	found := false
+	for price, level := range levels {
+		if !found || (highest && price > best.Price) || (!highest && price < best.Price) {
+			best = PriceLevel{Price: price, Size: level.value}
+			found = true
+		}
+	}
+	return best, found
+}
+
+// checkBest compares the local best price with the one reported by the server. An empty
+// side is reported as "0" (bids) or "1" (asks) or omitted.
+func checkBest(name string, reported string, levels map[float64]*bookLevel, highest bool) error {
+	if reported == "" {
+		return nil
+	}
+	want, err := strconv.ParseFloat(reported, 64)
+	if err != nil {
+		return nil
+	}
+
+	best, ok := bestLevel(levels, highest)
+	if !ok {
+		if want == 0 || want == 1 {
+			return nil
+		}
+		return fmt.Errorf("best %s mismatch: local none, server %s", name, reported)
+	}
+	if best.Price != want {
+		return fmt.Errorf("best %s mismatch: local %v, server %s", name, best.Price, reported)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package client_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/clobtest"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+func TestOrderBooksReplayMismatch(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+	books := client.NewOrderBooks(clobClient, nil)
+
+	// Hold the snapshot so the deltas below are buffered for the replay
+	srv.AddFault(clobtest.Fault{Method: http.MethodGet, Path: client.GetOrderBook, Delay: 200 * time.Millisecond, Times: 1})
+	done := make(chan error, 1)
+	go func() { done <- books.Resync("1") }()
+	waitFor(t, func() bool { return countRequests(srv, http.MethodGet, client.GetOrderBook) == 1 })
+
+	timestamp := time.Now().Add(time.Minute).UnixMilli()
+	for i, change := range []types.PriceChange{
+		{AssetID: "1", Price: "0.49", Size: "10", Side: types.SideBuy, BestBid: "0.49", BestAsk: "0.52"},
+		// The server reports a best bid the local book cannot have
+		{AssetID: "1", Price: "0.47", Size: "5", Side: types.SideBuy, BestBid: "0.45", BestAsk: "0.52"},
+		{AssetID: "1", Price: "0.53", Size: "10", Side: types.SideSell, BestBid: "0.49", BestAsk: "0.52"},
+	} {
+		books.OnPriceChange(&types.PriceChangeMessage{
+			EventType:    types.EventTypePriceChange,
+			Market:       "0xc0",
+			PriceChanges: []types.PriceChange{change},
+			Timestamp:    strconv.FormatInt(timestamp+int64(i), 10),
+		})
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Resync: %v", err)
+	}
+
+	// The replay stopped at the mismatch and the book is the bare snapshot
+	book, _ := books.Book("1")
+	want, _ := srv.Book("1")
+	if !book.Synced() || types.OrderBookHash(book.Snapshot()) != want.Hash {
+		t.Fatalf("book = %+v, want the snapshot %+v", book.Snapshot(), want)
+	}
+	if depth := book.Depth(types.SideBuy, 0); len(depth) != 1 || depth[0].Price != 0.48 {
+		t.Fatalf("bids = %+v", depth)
+	}
+}
+
+func TestOrderBooks(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	resyncs := make(chan error, 4)
+	books := client.NewOrderBooks(clobClient, &client.OrderBookConfig{
+		VerifyHash: true,
+		OnResync:   func(assetID string, reason error) { resyncs <- reason },
+	})
+	ws := client.NewWebSocketClient(clobClient, &client.WebSocketClientOptions{
+		URL:      srv.WebSocketURL(),
+		AssetIDs: []string{"1"},
+	})
+	ws.On(&client.WebSocketCallbacks{
+		OnBook:        books.OnBook,
+		OnPriceChange: books.OnPriceChange,
+	})
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer ws.Disconnect()
+
+	// The fake CLOB hashes with OrderBookHash too, so this checks the resync logic, not the
+	// formula. The websocket snapshot lacks tick size and min order size, so the first
+	// hash check resyncs from REST
+	waitForBook(t, books, srv, "1")
+
+	if err := srv.UpdateLevel("1", types.SideBuy, "0.5", "20"); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.UpdateLevel("1", types.SideSell, "0.52", "0"); err != nil {
+		t.Fatal(err)
+	}
+	waitForBook(t, books, srv, "1")
+
+	book, _ := books.Book("1")
+	if bid, ok := book.BestBid(); !ok || bid.Price != 0.5 || bid.Size != 20 {
+		t.Fatalf("unexpected best bid: %+v", bid)
+	}
+	if _, ok := book.BestAsk(); ok {
+		t.Fatal("ask was not removed")
+	}
+	if depth := book.Depth(types.SideBuy, 0); len(depth) != 2 || depth[1].Price != 0.48 {
+		t.Fatalf("unexpected depth: %+v", depth)
+	}
+
+	// A change the client never saw leaves it out of sync; the next delta resyncs it
+	for len(resyncs) > 0 {
+		<-resyncs
+	}
+	srv.SetBook("1", []types.OrderSummary{{Price: "0.4", Size: "5"}}, nil)
+	if err := srv.UpdateLevel("1", types.SideSell, "0.6", "7"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-resyncs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("gap did not trigger a resync")
+	}
+	waitForBook(t, books, srv, "1")
+}
+
+// waitForBook waits until the local book matches the server book
+func waitForBook(t *testing.T, books *client.OrderBooks, srv *clobtest.Server, assetID string) {
+	t.Helper()
+
+	want, _ := srv.Book(assetID)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if book, ok := books.Book(assetID); ok && book.Synced() &&
+			types.OrderBookHash(book.Snapshot()) == want.Hash {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("local book of %s did not converge to %+v", assetID, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
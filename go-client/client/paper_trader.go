@@ -0,0 +1,804 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// Paper order statuses, as reported by the open-orders API
+const (
+	paperStatusLive     = "LIVE"
+	paperStatusMatched  = "MATCHED"
+	paperStatusCanceled = "CANCELED"
+)
+
+// paperEpsilon absorbs float rounding when comparing sizes
+const paperEpsilon = 1e-9
+
+// PaperConfig configures a PaperTrader
+type PaperConfig struct {
+	// Balance is the starting virtual USDC balance
+	Balance float64
+	// Positions are the starting share balances keyed by token ID
+	Positions map[string]float64
+	// OnTrade is called for every simulated fill
+	OnTrade func(trade types.Trade)
+}
+
+// PaperBalances is a snapshot of a paper account
+type PaperBalances struct {
+	// USDC is available for new orders; LockedUSDC is reserved by open BUY orders
+	USDC       float64
+	LockedUSDC float64
+	// Shares are available per token ID; LockedShares are reserved by open SELL orders
+	Shares       map[string]float64
+	LockedShares map[string]float64
+}
+
+// PaperTrader simulates trading against real order books without touching funds.
+//
+// Books are seeded from GetOrderBook and kept current by feeding websocket messages
+// to OnBook, OnPriceChange and OnTickSizeChange. Marketable orders fill immediately
+// against the book at the book's prices and consume that liquidity. Resting orders fill
+// at their own price once the opposite side of the book reaches them; queue position
+// is not modelled. Fees follow the CTF exchange formula using the token's FeeRateBps.
+type PaperTrader struct {
+	marketData ClobAPI
+	onTrade    func(trade types.Trade)
+
+	mu           sync.Mutex
+	usdc         float64
+	lockedUSDC   float64
+	shares       map[string]float64
+	lockedShares map[string]float64
+	books        map[string]*paperBook
+	feeRates     map[string]int
+	orders       map[string]*paperOrder
+	orderIDs     []string
+	trades       []types.Trade
+	nextID       uint64
+}
+
+type paperBook struct {
+	market   string
+	tickSize types.TickSize
+	bids     map[float64]float64
+	asks     map[float64]float64
+}
+
+type paperOrder struct {
+	id         string
+	market     string
+	assetID    string
+	side       types.Side
+	price      float64
+	size       float64
+	matched    float64
+	notional   float64
+	feeRateBps int
+	orderType  types.OrderType
+	status     string
+	expiration int64
+	createdAt  int64
+	trades     []string
+}
+
+// NewPaperTrader creates a paper trading account that reads market data through marketData
+func NewPaperTrader(marketData ClobAPI, config *PaperConfig) *PaperTrader {
+	if config == nil {
+		config = &PaperConfig{}
+	}
+
+	shares := make(map[string]float64, len(config.Positions))
+	for tokenID, size := range config.Positions {
+		shares[tokenID] = size
+	}
+
+	return &PaperTrader{
+		marketData:   marketData,
+		onTrade:      config.OnTrade,
+		usdc:         config.Balance,
+		shares:       shares,
+		lockedShares: make(map[string]float64),
+		books:        make(map[string]*paperBook),
+		feeRates:     make(map[string]int),
+		orders:       make(map[string]*paperOrder),
+	}
+}
+
+// CreateAndPostOrder simulates placing a limit order. Rejections (insufficient balance,
+// unfillable FOK/FAK orders) are reported in the response, like the live API.
+func (p *PaperTrader) CreateAndPostOrder(userOrder *types.UserOrder, options *types.CreateOrderOptions, orderType types.OrderType) (*types.OrderResponse, error) {
+	if userOrder == nil {
+		return nil, fmt.Errorf("order is required")
+	}
+	if userOrder.Side != types.SideBuy && userOrder.Side != types.SideSell {
+		return nil, fmt.Errorf("invalid side: %s", userOrder.Side)
+	}
+	if orderType == "" {
+		orderType = types.OrderTypeGTC
+	}
+
+	if err := p.ensureBook(userOrder.TokenID); err != nil {
+		return nil, err
+	}
+	feeRateBps, err := p.feeRate(userOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+
+	book := p.books[userOrder.TokenID]
+	tickSize := book.tickSize
+	if options != nil && options.TickSize != "" {
+		tickSize = options.TickSize
+	}
+	round, ok := roundingConfig[tickSize]
+	if !ok {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("invalid tick size: %s", tickSize)
+	}
+	if !isPriceInRange(userOrder.Price, tickSize) {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("invalid price (%v), min: %s - max: %v", userOrder.Price, tickSize, 1-parseTickSize(tickSize))
+	}
+
+	price := roundNormal(userOrder.Price, int(round.Price))
+	size := roundDown(userOrder.Size, int(round.Size))
+	if size <= 0 {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("invalid size: %v", userOrder.Size)
+	}
+
+	if !p.canReserve(userOrder.TokenID, userOrder.Side, price, size) {
+		p.mu.Unlock()
+		return &types.OrderResponse{Success: false, ErrorMsg: "not enough balance / allowance"}, nil
+	}
+	if orderType == types.OrderTypeFOK && book.available(userOrder.Side, price) < size-paperEpsilon {
+		p.mu.Unlock()
+		return &types.OrderResponse{Success: false, ErrorMsg: "order couldn't be fully filled, FOK orders are fully filled or killed"}, nil
+	}
+
+	p.nextID++
+	order := &paperOrder{
+		id:         fmt.Sprintf("paper-order-%d", p.nextID),
+		market:     book.market,
+		assetID:    userOrder.TokenID,
+		side:       userOrder.Side,
+		price:      price,
+		size:       size,
+		feeRateBps: feeRateBps,
+		orderType:  orderType,
+		status:     paperStatusLive,
+		createdAt:  time.Now().Unix(),
+	}
+	if orderType == types.OrderTypeGTD && userOrder.Expiration != nil {
+		order.expiration = int64(*userOrder.Expiration)
+	}
+
+	p.reserve(order)
+	p.orders[order.id] = order
+	p.orderIDs = append(p.orderIDs, order.id)
+
+	fills := p.match(order, book, "TAKER")
+
+	status := "live"
+	switch {
+	case order.remaining() <= paperEpsilon:
+		order.status = paperStatusMatched
+		status = "matched"
+	case orderType == types.OrderTypeFAK || orderType == types.OrderTypeFOK:
+		p.release(order)
+		order.status = paperStatusCanceled
+		if order.matched == 0 {
+			p.mu.Unlock()
+			return &types.OrderResponse{Success: false, OrderID: order.id, ErrorMsg: "no orders found to match with FAK order"}, nil
+		}
+		status = "matched"
+	}
+
+	making, taking := order.notional, order.matched
+	if order.side == types.SideSell {
+		making, taking = taking, making
+	}
+
+	resp := &types.OrderResponse{
+		Success:      true,
+		OrderID:      order.id,
+		Status:       status,
+		MakingAmount: formatPaperAmount(making),
+		TakingAmount: formatPaperAmount(taking),
+	}
+	p.mu.Unlock()
+
+	p.emit(fills)
+	return resp, nil
+}
+
+// GetOrder returns a paper order by ID
+func (p *PaperTrader) GetOrder(orderID string) (*types.OpenOrder, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.expireOrders()
+
+	order, ok := p.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("order not found: %s", orderID)
+	}
+	openOrder := p.toOpenOrder(order)
+	return &openOrder, nil
+}
+
+// GetOpenOrders returns the resting paper orders; all orders are returned as one page
+func (p *PaperTrader) GetOpenOrders(params *types.OpenOrderParams, onlyFirstPage bool, nextCursor string) ([]types.OpenOrder, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.expireOrders()
+
+	var result []types.OpenOrder
+	for _, id := range p.orderIDs {
+		order := p.orders[id]
+		if order.status != paperStatusLive {
+			continue
+		}
+		if params != nil {
+			if params.ID != nil && *params.ID != order.id {
+				continue
+			}
+			if params.Market != nil && *params.Market != order.market {
+				continue
+			}
+			if params.AssetID != nil && *params.AssetID != order.assetID {
+				continue
+			}
+		}
+		result = append(result, p.toOpenOrder(order))
+	}
+	return result, nil
+}
+
+// GetTrades returns simulated fills; all trades are returned as one page
+func (p *PaperTrader) GetTrades(params *types.TradeParams, onlyFirstPage bool, nextCursor string) ([]types.Trade, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var result []types.Trade
+	for _, trade := range p.trades {
+		if params != nil {
+			if params.ID != nil && *params.ID != trade.ID {
+				continue
+			}
+			if params.Market != nil && *params.Market != trade.Market {
+				continue
+			}
+			if params.AssetID != nil && *params.AssetID != trade.AssetID {
+				continue
+			}
+			if params.MakerAddress != nil && *params.MakerAddress != trade.MakerAddress {
+				continue
+			}
+			matchTime, _ := strconv.ParseInt(trade.MatchTime, 10, 64)
+			if params.Before != nil {
+				if before, err := strconv.ParseInt(*params.Before, 10, 64); err == nil && matchTime >= before {
+					continue
+				}
+			}
+			if params.After != nil {
+				if after, err := strconv.ParseInt(*params.After, 10, 64); err == nil && matchTime <= after {
+					continue
+				}
+			}
+		}
+		result = append(result, trade)
+	}
+	return result, nil
+}
+
+// CancelOrder cancels a resting paper order and releases its reserved balance
+func (p *PaperTrader) CancelOrder(orderID string) (*types.CancelOrdersResponse, error) {
+	return p.CancelOrders([]string{orderID})
+}
+
+// CancelOrders cancels resting paper orders by ID
+func (p *PaperTrader) CancelOrders(orderIDs []string) (*types.CancelOrdersResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.expireOrders()
+
+	result := &types.CancelOrdersResponse{Canceled: []string{}, NotCanceled: map[string]string{}}
+	for _, id := range orderIDs {
+		order, ok := p.orders[id]
+		if !ok || order.status != paperStatusLive {
+			result.NotCanceled[id] = "order can't be found - already canceled or matched"
+			continue
+		}
+		p.cancel(order)
+		result.Canceled = append(result.Canceled, id)
+	}
+	return result, nil
+}
+
+// CancelAll cancels every resting paper order
+func (p *PaperTrader) CancelAll() (*types.CancelOrdersResponse, error) {
+	return p.CancelMarketOrders(nil)
+}
+
+// CancelMarketOrders cancels the resting paper orders of a market or asset
+func (p *PaperTrader) CancelMarketOrders(params *types.OrderMarketCancelParams) (*types.CancelOrdersResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.expireOrders()
+
+	result := &types.CancelOrdersResponse{Canceled: []string{}, NotCanceled: map[string]string{}}
+	for _, id := range p.orderIDs {
+		order := p.orders[id]
+		if order.status != paperStatusLive {
+			continue
+		}
+		if params != nil {
+			if params.Market != nil && *params.Market != order.market {
+				continue
+			}
+			if params.AssetID != nil && *params.AssetID != order.assetID {
+				continue
+			}
+		}
+		p.cancel(order)
+		result.Canceled = append(result.Canceled, id)
+	}
+	return result, nil
+}
+
+// Balances returns a snapshot of the virtual USDC and share balances
+func (p *PaperTrader) Balances() PaperBalances {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.expireOrders()
+
+	balances := PaperBalances{
+		USDC:         snapZero(p.usdc),
+		LockedUSDC:   snapZero(p.lockedUSDC),
+		Shares:       make(map[string]float64, len(p.shares)),
+		LockedShares: make(map[string]float64, len(p.lockedShares)),
+	}
+	for tokenID, size := range p.shares {
+		balances.Shares[tokenID] = snapZero(size)
+	}
+	for tokenID, size := range p.lockedShares {
+		balances.LockedShares[tokenID] = snapZero(size)
+	}
+	return balances
+}
+
+// SyncBook replaces the local book of a token with a fresh GetOrderBook snapshot
+// and matches resting orders against it
+func (p *PaperTrader) SyncBook(tokenID string) error {
+	summary, err := p.marketData.GetOrderBook(tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get order book: %w", err)
+	}
+
+	p.mu.Lock()
+	p.books[tokenID] = newPaperBook(summary.Market, types.TickSize(summary.TickSize), summary.Bids, summary.Asks)
+	fills := p.matchResting(tokenID)
+	p.mu.Unlock()
+
+	p.emit(fills)
+	return nil
+}
+
+// OnBook applies a websocket book snapshot; it can be used as WebSocketCallbacks.OnBook
+func (p *PaperTrader) OnBook(msg *types.BookMessage) {
+	p.mu.Lock()
+	tickSize := types.TickSize("")
+	if book, ok := p.books[msg.AssetID]; ok {
+		tickSize = book.tickSize
+	}
+	p.books[msg.AssetID] = newPaperBook(msg.Market, tickSize, msg.Bids, msg.Asks)
+	fills := p.matchResting(msg.AssetID)
+	p.mu.Unlock()
+
+	p.emit(fills)
+}
+
+// OnPriceChange applies websocket price level updates; it can be used as
+// WebSocketCallbacks.OnPriceChange. Updates for tokens without a seeded book are ignored.
+func (p *PaperTrader) OnPriceChange(msg *types.PriceChangeMessage) {
+	p.mu.Lock()
+
+	touched := make(map[string]bool)
+	for _, change := range msg.PriceChanges {
+		book, ok := p.books[change.AssetID]
+		if !ok {
+			continue
+		}
+		price, err := strconv.ParseFloat(change.Price, 64)
+		if err != nil {
+			continue
+		}
+		size, err := strconv.ParseFloat(change.Size, 64)
+		if err != nil {
+			continue
+		}
+
+		levels := book.asks
+		if change.Side == types.SideBuy {
+			levels = book.bids
+		}
+		if size <= 0 {
+			delete(levels, price)
+		} else {
+			levels[price] = size
+		}
+		touched[change.AssetID] = true
+	}
+
+	var fills []types.Trade
+	for assetID := range touched {
+		fills = append(fills, p.matchResting(assetID)...)
+	}
+	p.mu.Unlock()
+
+	p.emit(fills)
+}
+
+// OnTickSizeChange applies a websocket tick size change; it can be used as
+// WebSocketCallbacks.OnTickSizeChange
+func (p *PaperTrader) OnTickSizeChange(msg *types.TickSizeChangeMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if book, ok := p.books[msg.AssetID]; ok {
+		book.tickSize = types.TickSize(msg.NewTickSize)
+	}
+}
+
+// ensureBook seeds the local book of a token from GetOrderBook on first use, and
+// fetches the tick size of books seeded from websocket messages
+func (p *PaperTrader) ensureBook(tokenID string) error {
+	p.mu.Lock()
+	book, ok := p.books[tokenID]
+	hasTickSize := ok && book.tickSize != ""
+	p.mu.Unlock()
+
+	if hasTickSize {
+		return nil
+	}
+
+	if ok {
+		tickSize, err := p.marketData.GetTickSize(tokenID)
+		if err != nil {
+			return fmt.Errorf("failed to get tick size: %w", err)
+		}
+		p.mu.Lock()
+		if book, ok := p.books[tokenID]; ok && book.tickSize == "" {
+			book.tickSize = tickSize
+		}
+		p.mu.Unlock()
+		return nil
+	}
+
+	summary, err := p.marketData.GetOrderBook(tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get order book: %w", err)
+	}
+
+	p.mu.Lock()
+	if _, ok := p.books[tokenID]; !ok {
+		p.books[tokenID] = newPaperBook(summary.Market, types.TickSize(summary.TickSize), summary.Bids, summary.Asks)
+	}
+	p.mu.Unlock()
+	return nil
+}
+
+// feeRate returns the order's fee rate, fetching and caching the token's rate when unset
+func (p *PaperTrader) feeRate(userOrder *types.UserOrder) (int, error) {
+	if userOrder.FeeRateBps != nil {
+		return *userOrder.FeeRateBps, nil
+	}
+
+	p.mu.Lock()
+	rate, ok := p.feeRates[userOrder.TokenID]
+	p.mu.Unlock()
+	if ok {
+		return rate, nil
+	}
+
+	rate, err := p.marketData.GetFeeRateBps(userOrder.TokenID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fee rate: %w", err)
+	}
+
+	p.mu.Lock()
+	p.feeRates[userOrder.TokenID] = rate
+	p.mu.Unlock()
+	return rate, nil
+}
+
+func (p *PaperTrader) canReserve(tokenID string, side types.Side, price float64, size float64) bool {
+	if side == types.SideBuy {
+		return price*size <= p.usdc+paperEpsilon
+	}
+	return size <= p.shares[tokenID]+paperEpsilon
+}
+
+// reserve locks the balance an order needs: USDC at the limit price for BUY, shares for SELL
+func (p *PaperTrader) reserve(order *paperOrder) {
+	if order.side == types.SideBuy {
+		cost := order.price * order.size
+		p.usdc -= cost
+		p.lockedUSDC += cost
+		return
+	}
+	p.shares[order.assetID] -= order.size
+	p.lockedShares[order.assetID] += order.size
+}
+
+// release unlocks the balance reserved for the unfilled part of an order
+func (p *PaperTrader) release(order *paperOrder) {
+	remaining := order.remaining()
+	if order.side == types.SideBuy {
+		p.usdc += order.price * remaining
+		p.lockedUSDC -= order.price * remaining
+		return
+	}
+	p.shares[order.assetID] += remaining
+	p.lockedShares[order.assetID] -= remaining
+}
+
+func (p *PaperTrader) cancel(order *paperOrder) {
+	p.release(order)
+	order.status = paperStatusCanceled
+}
+
+// expireOrders cancels GTD orders past their expiration
+func (p *PaperTrader) expireOrders() {
+	now := time.Now().Unix()
+	for _, order := range p.orders {
+		if order.status == paperStatusLive && order.expiration > 0 && now >= order.expiration {
+			p.cancel(order)
+		}
+	}
+}
+
+// matchResting fills resting orders of a token that the book has crossed,
+// best priced orders first, then oldest first
+func (p *PaperTrader) matchResting(assetID string) []types.Trade {
+	p.expireOrders()
+
+	book, ok := p.books[assetID]
+	if !ok {
+		return nil
+	}
+
+	var resting []*paperOrder
+	for _, id := range p.orderIDs {
+		order := p.orders[id]
+		if order.status == paperStatusLive && order.assetID == assetID {
+			resting = append(resting, order)
+		}
+	}
+	sort.SliceStable(resting, func(i, j int) bool {
+		if resting[i].side != resting[j].side {
+			return resting[i].side == types.SideBuy
+		}
+		if resting[i].side == types.SideBuy {
+			return resting[i].price > resting[j].price
+		}
+		return resting[i].price < resting[j].price
+	})
+
+	var fills []types.Trade
+	for _, order := range resting {
+		fills = append(fills, p.match(order, book, "MAKER")...)
+		if order.remaining() <= paperEpsilon {
+			order.status = paperStatusMatched
+		}
+	}
+	return fills
+}
+
+// match fills an order against crossing book levels and consumes that liquidity.
+// Takers trade at the book's prices, makers at their own price.
+func (p *PaperTrader) match(order *paperOrder, book *paperBook, traderSide string) []types.Trade {
+	var fills []types.Trade
+	for _, level := range book.crossing(order.side, order.price) {
+		remaining := order.remaining()
+		if remaining <= paperEpsilon {
+			break
+		}
+
+		size := math.Min(remaining, level.size)
+		price := level.price
+		if traderSide == "MAKER" {
+			price = order.price
+		}
+		fills = append(fills, p.fill(order, price, size, traderSide))
+
+		levels := book.levels(opposite(order.side))
+		levels[level.price] -= size
+		if levels[level.price] <= paperEpsilon {
+			delete(levels, level.price)
+		}
+	}
+	return fills
+}
+
+// fill settles part of an order and records the trade. Fees are charged on proceeds:
+// shares for BUY (rate * min(p, 1-p) * size / p), USDC for SELL (rate * min(p, 1-p) * size).
+func (p *PaperTrader) fill(order *paperOrder, price float64, size float64, traderSide string) types.Trade {
+	fee := float64(order.feeRateBps) / 10000 * math.Min(price, 1-price) * size
+
+	if order.side == types.SideBuy {
+		// USDC was reserved at the limit price; refund the price improvement
+		p.lockedUSDC -= order.price * size
+		p.usdc += (order.price - price) * size
+		p.shares[order.assetID] += size - fee/price
+	} else {
+		p.lockedShares[order.assetID] -= size
+		p.usdc += price*size - fee
+	}
+	order.matched += size
+	order.notional += price * size
+
+	p.nextID++
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	trade := types.Trade{
+		ID:           fmt.Sprintf("paper-trade-%d", p.nextID),
+		Market:       order.market,
+		AssetID:      order.assetID,
+		Side:         order.side,
+		Size:         formatPaperAmount(size),
+		FeeRateBps:   strconv.Itoa(order.feeRateBps),
+		Price:        formatPaperAmount(price),
+		Status:       "CONFIRMED",
+		MatchTime:    now,
+		LastUpdate:   now,
+		MakerAddress: p.marketData.GetFunderAddress(),
+		TraderSide:   traderSide,
+	}
+	if traderSide == "TAKER" {
+		trade.TakerOrderID = order.id
+	} else {
+		trade.MakerOrders = []types.MakerOrder{{
+			OrderID:       order.id,
+			MakerAddress:  trade.MakerAddress,
+			MatchedAmount: trade.Size,
+			Price:         trade.Price,
+			FeeRateBps:    trade.FeeRateBps,
+			AssetID:       order.assetID,
+			Side:          order.side,
+		}}
+	}
+
+	order.trades = append(order.trades, trade.ID)
+	p.trades = append(p.trades, trade)
+	return trade
+}
+
+// emit reports fills outside the lock so callbacks may call back into the trader
+func (p *PaperTrader) emit(fills []types.Trade) {
+	if p.onTrade == nil {
+		return
+	}
+	for _, trade := range fills {
+		p.onTrade(trade)
+	}
+}
+
+func (p *PaperTrader) toOpenOrder(order *paperOrder) types.OpenOrder {
+	return types.OpenOrder{
+		ID:              order.id,
+		Status:          order.status,
+		MakerAddress:    p.marketData.GetFunderAddress(),
+		Market:          order.market,
+		AssetID:         order.assetID,
+		Side:            string(order.side),
+		OriginalSize:    formatPaperAmount(order.size),
+		SizeMatched:     formatPaperAmount(order.matched),
+		Price:           formatPaperAmount(order.price),
+		AssociateTrades: append([]string{}, order.trades...),
+		CreatedAt:       order.createdAt,
+		Expiration:      strconv.FormatInt(order.expiration, 10),
+		OrderType:       string(order.orderType),
+	}
+}
+
+func (o *paperOrder) remaining() float64 {
+	return o.size - o.matched
+}
+
+type paperLevel struct {
+	price float64
+	size  float64
+}
+
+func newPaperBook(market string, tickSize types.TickSize, bids []types.OrderSummary, asks []types.OrderSummary) *paperBook {
+	return &paperBook{
+		market:   market,
+		tickSize: tickSize,
+		bids:     paperLevels(bids),
+		asks:     paperLevels(asks),
+	}
+}
+
+func paperLevels(summaries []types.OrderSummary) map[float64]float64 {
+	levels := make(map[float64]float64, len(summaries))
+	for _, summary := range summaries {
+		price, err := strconv.ParseFloat(summary.Price, 64)
+		if err != nil {
+			continue
+		}
+		size, err := strconv.ParseFloat(summary.Size, 64)
+		if err != nil || size <= 0 {
+			continue
+		}
+		levels[price] = size
+	}
+	return levels
+}
+
+func (b *paperBook) levels(side types.Side) map[float64]float64 {
+	if side == types.SideBuy {
+		return b.bids
+	}
+	return b.asks
+}
+
+// crossing returns the opposite side's levels an order at price would trade with, best first
+func (b *paperBook) crossing(side types.Side, price float64) []paperLevel {
+	var levels []paperLevel
+	for levelPrice, size := range b.levels(opposite(side)) {
+		if (side == types.SideBuy && levelPrice <= price+paperEpsilon) ||
+			(side == types.SideSell && levelPrice >= price-paperEpsilon) {
+			levels = append(levels, paperLevel{price: levelPrice, size: size})
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if side == types.SideBuy {
+			return levels[i].price < levels[j].price
+		}
+		return levels[i].price > levels[j].price
+	})
+	return levels
+}
+
+// available returns the size an order at price could fill immediately
+func (b *paperBook) available(side types.Side, price float64) float64 {
+	var total float64
+	for _, level := range b.crossing(side, price) {
+		total += level.size
+	}
+	return total
+}
+
+func opposite(side types.Side) types.Side {
+	if side == types.SideBuy {
+		return types.SideSell
+	}
+	return types.SideBuy
+}
+
+func formatPaperAmount(amount float64) string {
+	return strconv.FormatFloat(roundNormal(amount, collateralDecimals), 'f', -1, 64)
+}
+
+// snapZero hides float residue left after reserving and releasing the same amount
+func snapZero(value float64) float64 {
+	if math.Abs(value) < paperEpsilon {
+		return 0
+	}
+	return value
+}
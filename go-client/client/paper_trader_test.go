@@ -0,0 +1,239 @@
+package client_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/clobtest"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// newPaperTrader returns a paper account trading token 10, which charges 100 bps and
+// has asks of 50 at 0.6 and 0.7 and a bid of 50 at 0.4
+func newPaperTrader(t *testing.T, config *client.PaperConfig) *client.PaperTrader {
+	t.Helper()
+
+	srv, clobClient := newTestClient(t)
+	srv.AddMarket(clobtest.Market{
+		ConditionID: "0xfee",
+		Tokens:      []clobtest.Token{{TokenID: "10", Outcome: "Yes"}, {TokenID: "11", Outcome: "No"}},
+		FeeRateBps:  100,
+	})
+	srv.SetBook("10",
+		[]types.OrderSummary{{Price: "0.4", Size: "50"}},
+		[]types.OrderSummary{{Price: "0.6", Size: "50"}, {Price: "0.7", Size: "50"}})
+
+	return client.NewPaperTrader(clobClient, config)
+}
+
+func assertClose(t *testing.T, name string, got float64, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-6 {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestPaperTraderTakerFill(t *testing.T) {
+	var emitted []types.Trade
+	paper := newPaperTrader(t, &client.PaperConfig{
+		Balance: 100,
+		OnTrade: func(trade types.Trade) { emitted = append(emitted, trade) },
+	})
+
+	// Sweeps the 0.6 level and takes 10 from the 0.7 level
+	resp, err := paper.CreateAndPostOrder(&types.UserOrder{TokenID: "10", Price: 0.7, Size: 60, Side: types.SideBuy}, nil, types.OrderTypeGTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Success || resp.Status != "matched" || resp.MakingAmount != "37" || resp.TakingAmount != "60" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	trades, _ := paper.GetTrades(nil, false, "")
+	if len(trades) != 2 || len(emitted) != 2 {
+		t.Fatalf("trades = %+v, emitted = %+v", trades, emitted)
+	}
+	for i, want := range []struct{ price, size string }{{"0.6", "50"}, {"0.7", "10"}} {
+		trade := trades[i]
+		if trade.Price != want.price || trade.Size != want.size || trade.TraderSide != "TAKER" ||
+			trade.FeeRateBps != "100" || trade.TakerOrderID != resp.OrderID {
+			t.Errorf("trade %d = %+v", i, trade)
+		}
+	}
+
+	// Paid at the book's prices, the rest of the 0.7 reservation is refunded. The fee,
+	// rate * min(p, 1-p) * size, is taken in shares at the fill price:
+	// 0.01*0.4*50/0.6 + 0.01*0.3*10/0.7
+	balances := paper.Balances()
+	assertClose(t, "USDC", balances.USDC, 63)
+	assertClose(t, "locked USDC", balances.LockedUSDC, 0)
+	assertClose(t, "shares", balances.Shares["10"], 60-0.2/0.6-0.03/0.7)
+
+	// The liquidity is consumed: the next buy at 0.6 finds nothing and rests
+	resp, err = paper.CreateAndPostOrder(&types.UserOrder{TokenID: "10", Price: 0.6, Size: 5, Side: types.SideBuy}, nil, types.OrderTypeGTC)
+	if err != nil || !resp.Success || resp.Status != "live" {
+		t.Fatalf("second order = %+v, %v", resp, err)
+	}
+}
+
+func TestPaperTraderMakerFill(t *testing.T) {
+	var emitted []types.Trade
+	paper := newPaperTrader(t, &client.PaperConfig{
+		Positions: map[string]float64{"10": 20},
+		OnTrade:   func(trade types.Trade) { emitted = append(emitted, trade) },
+	})
+
+	resp, err := paper.CreateAndPostOrder(&types.UserOrder{TokenID: "10", Price: 0.65, Size: 20, Side: types.SideSell}, nil, types.OrderTypeGTC)
+	if err != nil || !resp.Success || resp.Status != "live" {
+		t.Fatalf("CreateAndPostOrder = %+v, %v", resp, err)
+	}
+	if balances := paper.Balances(); balances.Shares["10"] != 0 || balances.LockedShares["10"] != 20 {
+		t.Fatalf("balances while resting = %+v", balances)
+	}
+
+	// A bid above the resting ask fills it at the order's own price
+	paper.OnPriceChange(&types.PriceChangeMessage{
+		EventType:    types.EventTypePriceChange,
+		Market:       "0xfee",
+		PriceChanges: []types.PriceChange{{AssetID: "10", Price: "0.66", Size: "30", Side: types.SideBuy}},
+	})
+
+	if len(emitted) != 1 {
+		t.Fatalf("emitted = %+v", emitted)
+	}
+	trade := emitted[0]
+	if trade.TraderSide != "MAKER" || trade.Price != "0.65" || trade.Size != "20" ||
+		len(trade.MakerOrders) != 1 || trade.MakerOrders[0].OrderID != resp.OrderID {
+		t.Fatalf("unexpected trade: %+v", trade)
+	}
+
+	// SELL fees are taken in USDC: 0.01 * 0.35 * 20
+	balances := paper.Balances()
+	assertClose(t, "USDC", balances.USDC, 13-0.07)
+	assertClose(t, "locked shares", balances.LockedShares["10"], 0)
+
+	order, err := paper.GetOrder(resp.OrderID)
+	if err != nil || order.Status != "MATCHED" || order.SizeMatched != "20" {
+		t.Fatalf("GetOrder = %+v, %v", order, err)
+	}
+	if open, _ := paper.GetOpenOrders(nil, false, ""); len(open) != 0 {
+		t.Errorf("open orders = %+v", open)
+	}
+}
+
+func TestPaperTraderFOKAndFAK(t *testing.T) {
+	paper := newPaperTrader(t, &client.PaperConfig{Balance: 100})
+
+	// Only 50 is offered at or below 0.6, so FOK is killed without touching anything
+	order := &types.UserOrder{TokenID: "10", Price: 0.6, Size: 80, Side: types.SideBuy}
+	resp, err := paper.CreateAndPostOrder(order, nil, types.OrderTypeFOK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Success {
+		t.Fatalf("FOK partially fillable order accepted: %+v", resp)
+	}
+	if trades, _ := paper.GetTrades(nil, false, ""); len(trades) != 0 {
+		t.Fatalf("FOK traded: %+v", trades)
+	}
+	assertClose(t, "USDC after FOK", paper.Balances().USDC, 100)
+
+	// FAK keeps the 50 it can fill and cancels the rest
+	resp, err = paper.CreateAndPostOrder(order, nil, types.OrderTypeFAK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Success || resp.Status != "matched" || resp.TakingAmount != "50" {
+		t.Fatalf("FAK response = %+v", resp)
+	}
+	balances := paper.Balances()
+	assertClose(t, "USDC after FAK", balances.USDC, 70)
+	assertClose(t, "locked USDC after FAK", balances.LockedUSDC, 0)
+
+	status, _ := paper.GetOrder(resp.OrderID)
+	if status.Status != "CANCELED" || status.SizeMatched != "50" {
+		t.Fatalf("FAK order = %+v", status)
+	}
+	if open, _ := paper.GetOpenOrders(nil, false, ""); len(open) != 0 {
+		t.Errorf("open orders = %+v", open)
+	}
+
+	// With the 0.6 level gone, a FAK at 0.6 has nothing to match
+	resp, _ = paper.CreateAndPostOrder(order, nil, types.OrderTypeFAK)
+	if resp.Success {
+		t.Errorf("unmatched FAK accepted: %+v", resp)
+	}
+}
+
+func TestPaperTraderCancelReleasesBalance(t *testing.T) {
+	paper := newPaperTrader(t, &client.PaperConfig{Balance: 10, Positions: map[string]float64{"10": 5}})
+
+	buy, _ := paper.CreateAndPostOrder(&types.UserOrder{TokenID: "10", Price: 0.3, Size: 20, Side: types.SideBuy}, nil, types.OrderTypeGTC)
+	sell, _ := paper.CreateAndPostOrder(&types.UserOrder{TokenID: "10", Price: 0.9, Size: 5, Side: types.SideSell}, nil, types.OrderTypeGTC)
+	if !buy.Success || !sell.Success {
+		t.Fatalf("orders = %+v, %+v", buy, sell)
+	}
+
+	balances := paper.Balances()
+	assertClose(t, "USDC", balances.USDC, 4)
+	assertClose(t, "locked USDC", balances.LockedUSDC, 6)
+	assertClose(t, "locked shares", balances.LockedShares["10"], 5)
+
+	// Reserved funds are not available to other orders
+	resp, _ := paper.CreateAndPostOrder(&types.UserOrder{TokenID: "10", Price: 0.3, Size: 20, Side: types.SideBuy}, nil, types.OrderTypeGTC)
+	if resp.Success {
+		t.Fatalf("order beyond the available balance accepted: %+v", resp)
+	}
+
+	canceled, err := paper.CancelOrder(buy.OrderID)
+	if err != nil || len(canceled.Canceled) != 1 {
+		t.Fatalf("CancelOrder = %+v, %v", canceled, err)
+	}
+	if canceled, _ := paper.CancelOrder(buy.OrderID); len(canceled.NotCanceled) != 1 {
+		t.Errorf("second cancel = %+v", canceled)
+	}
+	if _, err := paper.CancelAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	balances = paper.Balances()
+	assertClose(t, "USDC after cancel", balances.USDC, 10)
+	assertClose(t, "locked USDC after cancel", balances.LockedUSDC, 0)
+	assertClose(t, "shares after cancel", balances.Shares["10"], 5)
+	assertClose(t, "locked shares after cancel", balances.LockedShares["10"], 0)
+}
+
+func TestPaperTraderGTDExpiry(t *testing.T) {
+	paper := newPaperTrader(t, &client.PaperConfig{Balance: 10})
+
+	expiration := int(time.Now().Unix()) + 1
+	resp, err := paper.CreateAndPostOrder(&types.UserOrder{
+		TokenID:    "10",
+		Price:      0.3,
+		Size:       10,
+		Side:       types.SideBuy,
+		Expiration: &expiration,
+	}, nil, types.OrderTypeGTD)
+	if err != nil || !resp.Success {
+		t.Fatalf("CreateAndPostOrder = %+v, %v", resp, err)
+	}
+	if open, _ := paper.GetOpenOrders(nil, false, ""); len(open) != 1 || open[0].OrderType != "GTD" {
+		t.Fatalf("open orders before expiry = %+v", open)
+	}
+	assertClose(t, "locked USDC", paper.Balances().LockedUSDC, 3)
+
+	time.Sleep(time.Until(time.Unix(int64(expiration), 0)))
+
+	if open, _ := paper.GetOpenOrders(nil, false, ""); len(open) != 0 {
+		t.Fatalf("open orders after expiry = %+v", open)
+	}
+	order, _ := paper.GetOrder(resp.OrderID)
+	if order.Status != "CANCELED" {
+		t.Errorf("expired order status = %s", order.Status)
+	}
+	balances := paper.Balances()
+	assertClose(t, "USDC after expiry", balances.USDC, 10)
+	assertClose(t, "locked USDC after expiry", balances.LockedUSDC, 0)
+}
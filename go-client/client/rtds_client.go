@@ -0,0 +1,335 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+const (
+	rtdsURL                 = "wss://ws-live-data.polymarket.com"
+	defaultRTDSPingInterval = 5 * time.Second
+)
+
+// RTDSClientOptions configures the real-time data service client
+type RTDSClientOptions struct {
+	// WebSocket URL (default wss://ws-live-data.polymarket.com)
+	URL string
+
+	// Subscriptions to send on connect; change them later with Subscribe and Unsubscribe
+	Subscriptions []types.RTDSSubscription
+
+	// Whether to auto-reconnect on disconnect
+	AutoReconnect bool
+
+	// Delay before the first reconnection attempt, doubled after every failed attempt up
+	// to MaxReconnectDelay (default 1s)
+	ReconnectDelay time.Duration
+
+	// Upper bound of the reconnection delay (default 30s)
+	MaxReconnectDelay time.Duration
+
+	// Fraction of each reconnection delay that is randomized (default 0.2, negative disables)
+	ReconnectJitter float64
+
+	// Maximum number of consecutive reconnection attempts (0 = infinite)
+	MaxReconnectAttempts int
+
+	// Interval between PINGs (default 5s)
+	PingInterval time.Duration
+
+	// How long after a missed PONG the connection is considered dead (default 10s)
+	PongTimeout time.Duration
+
+	// Enable debug logging
+	Debug bool
+
+	// Custom logger (if nil, uses default log.Logger)
+	Logger *log.Logger
+}
+
+// RTDSCallbacks holds callback functions for RTDS messages and connection events. Like
+// WebSocketCallbacks they run on the read goroutine.
+type RTDSCallbacks struct {
+	// OnActivityTrade receives activity trades and orders_matched messages
+	OnActivityTrade func(msgType types.RTDSMessageType, trade *types.ActivityTrade)
+	// OnComment receives comment_created and comment_removed messages
+	OnComment func(msgType types.RTDSMessageType, comment *types.Comment)
+	// OnReaction receives reaction_created and reaction_removed messages
+	OnReaction func(msgType types.RTDSMessageType, reaction *types.Reaction)
+	// OnCryptoPrice receives crypto_prices updates
+	OnCryptoPrice func(price *types.CryptoPrice)
+	// OnMessage receives every message, including those of other topics
+	OnMessage func(msg *types.RTDSMessage)
+
+	OnError       func(error)
+	OnConnect     func()
+	OnDisconnect  func(code int, reason string)
+	OnReconnect   func(attempt int)
+	OnStateChange func(state ConnectionState)
+}
+
+// RTDSClient streams Polymarket's real-time data service: trade activity, comments and
+// reactions, and crypto prices. It runs on a WebSocketClient, so connections, PINGs,
+// reconnection with backoff and the session lifecycle behave the same way; subscriptions
+// are restored on every reconnect.
+type RTDSClient struct {
+	ws            *WebSocketClient
+	callbacks     *RTDSCallbacks
+	subscriptions *rtdsSubscriptionSet
+}
+
+// NewRTDSClient creates a real-time data service client
+func NewRTDSClient(options *RTDSClientOptions) *RTDSClient {
+	if options == nil {
+		options = &RTDSClientOptions{}
+	}
+	if options.URL == "" {
+		options.URL = rtdsURL
+	}
+	if options.PingInterval <= 0 {
+		options.PingInterval = defaultRTDSPingInterval
+	}
+
+	c := &RTDSClient{
+		callbacks:     &RTDSCallbacks{},
+		subscriptions: &rtdsSubscriptionSet{},
+	}
+	c.subscriptions.add(options.Subscriptions)
+
+	c.ws = NewWebSocketClient(nil, &WebSocketClientOptions{
+		URL:                  options.URL,
+		AutoReconnect:        options.AutoReconnect,
+		ReconnectDelay:       options.ReconnectDelay,
+		MaxReconnectDelay:    options.MaxReconnectDelay,
+		ReconnectJitter:      options.ReconnectJitter,
+		MaxReconnectAttempts: options.MaxReconnectAttempts,
+		PingInterval:         options.PingInterval,
+		PongTimeout:          options.PongTimeout,
+		Debug:                options.Debug,
+		Logger:               options.Logger,
+	})
+	c.ws.protocol = &wsProtocol{
+		ping:         "PING",
+		pong:         "PONG",
+		subscription: c.subscriptionMessage,
+		handle:       c.handle,
+	}
+	c.On(c.callbacks)
+	return c
+}
+
+// On registers event handlers
+func (c *RTDSClient) On(callbacks *RTDSCallbacks) *RTDSClient {
+	c.callbacks = callbacks
+	c.ws.On(&WebSocketCallbacks{
+		OnError:       callbacks.OnError,
+		OnConnect:     callbacks.OnConnect,
+		OnDisconnect:  callbacks.OnDisconnect,
+		OnReconnect:   callbacks.OnReconnect,
+		OnStateChange: callbacks.OnStateChange,
+	})
+	return c
+}
+
+// Connect establishes the connection and starts a session, as WebSocketClient.Connect
+func (c *RTDSClient) Connect() error {
+	return c.ws.Connect()
+}
+
+// Disconnect closes the connection and stops reconnecting, as WebSocketClient.Disconnect
+func (c *RTDSClient) Disconnect() {
+	c.ws.Disconnect()
+}
+
+// Close disconnects and waits until the session's goroutines have exited or ctx is done
+func (c *RTDSClient) Close(ctx context.Context) error {
+	return c.ws.Close(ctx)
+}
+
+// Done returns a channel that is closed when the current (or last) session ends
+func (c *RTDSClient) Done() <-chan struct{} {
+	return c.ws.Done()
+}
+
+// Wait blocks until the current session ends
+func (c *RTDSClient) Wait() {
+	c.ws.Wait()
+}
+
+// State returns the lifecycle state
+func (c *RTDSClient) State() ConnectionState {
+	return c.ws.State()
+}
+
+// IsConnected returns whether the connection is up
+func (c *RTDSClient) IsConnected() bool {
+	return c.ws.IsConnected()
+}
+
+// Subscribe adds subscriptions; those not already present are sent on the open connection
+func (c *RTDSClient) Subscribe(subscriptions ...types.RTDSSubscription) error {
+	added := c.subscriptions.add(subscriptions)
+	if len(added) == 0 || !c.ws.IsConnected() {
+		return nil
+	}
+
+	c.ws.log("Subscribing:", added)
+	return c.ws.writeJSON(rtdsAction{Action: "subscribe", Subscriptions: added})
+}
+
+// Unsubscribe removes subscriptions, matched by topic, type and filters, and tells the
+// server to stop sending their messages
+func (c *RTDSClient) Unsubscribe(subscriptions ...types.RTDSSubscription) error {
+	removed := c.subscriptions.remove(subscriptions)
+	if len(removed) == 0 || !c.ws.IsConnected() {
+		return nil
+	}
+
+	c.ws.log("Unsubscribing:", removed)
+	return c.ws.writeJSON(rtdsAction{Action: "unsubscribe", Subscriptions: removed})
+}
+
+// Subscriptions returns the current subscriptions in subscription order
+func (c *RTDSClient) Subscriptions() []types.RTDSSubscription {
+	return c.subscriptions.list()
+}
+
+// rtdsAction is a subscribe or unsubscribe request
+type rtdsAction struct {
+	Action        string                   `json:"action"`
+	Subscriptions []types.RTDSSubscription `json:"subscriptions"`
+}
+
+// subscriptionMessage opens every connection with the full subscription set
+func (c *RTDSClient) subscriptionMessage() interface{} {
+	subscriptions := c.subscriptions.list()
+	if len(subscriptions) == 0 {
+		return nil
+	}
+	return rtdsAction{Action: "subscribe", Subscriptions: subscriptions}
+}
+
+func (c *RTDSClient) handle(data []byte) {
+	// The server may answer PINGs in lower case and sends empty keep-alive frames
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.EqualFold(data, []byte("pong")) {
+		return
+	}
+
+	msg, err := types.ParseRTDSMessage(data)
+	if err != nil {
+		c.ws.handleError(err)
+		c.ws.log("Raw message:", string(data))
+		return
+	}
+
+	callbacks := c.callbacks
+	switch msg.Topic {
+	case types.RTDSTopicActivity:
+		if callbacks.OnActivityTrade != nil {
+			if trade, err := msg.ActivityTrade(); err != nil {
+				c.ws.handleError(err)
+			} else {
+				callbacks.OnActivityTrade(msg.Type, trade)
+			}
+		}
+	case types.RTDSTopicComments:
+		switch msg.Type {
+		case types.RTDSTypeCommentCreated, types.RTDSTypeCommentRemoved:
+			if callbacks.OnComment != nil {
+				if comment, err := msg.Comment(); err != nil {
+					c.ws.handleError(err)
+				} else {
+					callbacks.OnComment(msg.Type, comment)
+				}
+			}
+		case types.RTDSTypeReactionCreated, types.RTDSTypeReactionRemoved:
+			if callbacks.OnReaction != nil {
+				if reaction, err := msg.Reaction(); err != nil {
+					c.ws.handleError(err)
+				} else {
+					callbacks.OnReaction(msg.Type, reaction)
+				}
+			}
+		}
+	case types.RTDSTopicCryptoPrices:
+		if callbacks.OnCryptoPrice != nil {
+			if price, err := msg.CryptoPrice(); err != nil {
+				c.ws.handleError(err)
+			} else {
+				callbacks.OnCryptoPrice(price)
+			}
+		}
+	}
+
+	if callbacks.OnMessage != nil {
+		callbacks.OnMessage(msg)
+	}
+}
+
+// rtdsSubscriptionSet is an insertion-ordered set of subscriptions
+type rtdsSubscriptionSet struct {
+	mu            sync.Mutex
+	subscriptions []types.RTDSSubscription
+}
+
+// add inserts the subscriptions and returns the ones that were not present
+func (s *rtdsSubscriptionSet) add(subscriptions []types.RTDSSubscription) []types.RTDSSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var added []types.RTDSSubscription
+	for _, subscription := range subscriptions {
+		if subscription.Topic == "" || s.index(subscription) >= 0 {
+			continue
+		}
+		if subscription.Type == "" {
+			subscription.Type = types.RTDSTypeAll
+		}
+		s.subscriptions = append(s.subscriptions, subscription)
+		added = append(added, subscription)
+	}
+	return added
+}
+
+// remove deletes the subscriptions and returns the ones that were present
+func (s *rtdsSubscriptionSet) remove(subscriptions []types.RTDSSubscription) []types.RTDSSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var removed []types.RTDSSubscription
+	for _, subscription := range subscriptions {
+		i := s.index(subscription)
+		if i < 0 {
+			continue
+		}
+		removed = append(removed, s.subscriptions[i])
+		s.subscriptions = append(s.subscriptions[:i], s.subscriptions[i+1:]...)
+	}
+	return removed
+}
+
+// index finds a subscription; an empty Type means all types, as the server reads it
+func (s *rtdsSubscriptionSet) index(subscription types.RTDSSubscription) int {
+	if subscription.Type == "" {
+		subscription.Type = types.RTDSTypeAll
+	}
+	for i, existing := range s.subscriptions {
+		if existing == subscription {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *rtdsSubscriptionSet) list() []types.RTDSSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]types.RTDSSubscription{}, s.subscriptions...)
+}
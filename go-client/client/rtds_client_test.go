@@ -0,0 +1,87 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/clobtest"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+func TestRTDSClient(t *testing.T) {
+	srv := clobtest.NewRTDSServer()
+	defer srv.Close()
+
+	trades := make(chan *types.ActivityTrade, 4)
+	reactions := make(chan types.RTDSMessageType, 4)
+	connected := make(chan struct{}, 4)
+	rtds := client.NewRTDSClient(&client.RTDSClientOptions{
+		URL: srv.WebSocketURL(),
+		Subscriptions: []types.RTDSSubscription{{
+			Topic:   types.RTDSTopicActivity,
+			Type:    types.RTDSTypeTrades,
+			Filters: types.ActivityFilter("fed-decision", ""),
+		}},
+		AutoReconnect:  true,
+		ReconnectDelay: 10 * time.Millisecond,
+	})
+	rtds.On(&client.RTDSCallbacks{
+		OnActivityTrade: func(msgType types.RTDSMessageType, trade *types.ActivityTrade) { trades <- trade },
+		OnReaction:      func(msgType types.RTDSMessageType, reaction *types.Reaction) { reactions <- msgType },
+		OnConnect:       func() { connected <- struct{}{} },
+	})
+	if err := rtds.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer rtds.Disconnect()
+	<-connected
+
+	waitFor(t, func() bool { return len(srv.Subscriptions()) == 1 })
+	if got := srv.Subscriptions()[0].Filters; got != `{"event_slug":"fed-decision"}` {
+		t.Errorf("filters = %s", got)
+	}
+
+	srv.Publish(types.RTDSTopicActivity, types.RTDSTypeTrades, map[string]interface{}{
+		"asset": "1", "side": "BUY", "price": 0.6, "size": 50000, "eventSlug": "fed-decision",
+	})
+	select {
+	case trade := <-trades:
+		if trade.Asset != "1" || trade.Side != types.SideBuy || trade.Notional() != 30000 {
+			t.Fatalf("unexpected trade: %+v", trade)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no activity trade received")
+	}
+
+	comments := types.RTDSSubscription{Topic: types.RTDSTopicComments, Filters: types.CommentsFilter("Event", 7)}
+	if err := rtds.Subscribe(comments); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(srv.Subscriptions()) == 2 })
+
+	// Subscriptions are restored on reconnect
+	srv.DisconnectWebSockets()
+	<-connected
+	waitFor(t, func() bool { return len(srv.Subscriptions()) == 2 })
+
+	srv.Publish(types.RTDSTopicComments, types.RTDSTypeReactionCreated, map[string]interface{}{
+		"id": "9", "commentID": 3, "reactionType": "HEART",
+	})
+	select {
+	case msgType := <-reactions:
+		if msgType != types.RTDSTypeReactionCreated {
+			t.Fatalf("unexpected reaction type %s", msgType)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no reaction received")
+	}
+
+	if err := rtds.Unsubscribe(comments); err != nil {
+		t.Fatal(err)
+	}
+	if got := rtds.Subscriptions(); len(got) != 1 || got[0].Topic != types.RTDSTopicActivity {
+		t.Errorf("Subscriptions = %+v", got)
+	}
+	waitFor(t, func() bool { return len(srv.Subscriptions()) == 1 })
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// TradingMode selects whether orders reach the exchange or a local simulation
+type TradingMode string
+
+const (
+	// TradingModeLive sends orders to the CLOB
+	TradingModeLive TradingMode = "live"
+	// TradingModePaper matches orders locally against real order books
+	TradingModePaper TradingMode = "paper"
+)
+
+// Trader is the order, cancel and open-orders API shared by the live ClobClient and
+// the PaperTrader, so strategies can switch between them with TradingMode
+type Trader interface {
+	CreateAndPostOrder(userOrder *types.UserOrder, options *types.CreateOrderOptions, orderType types.OrderType) (*types.OrderResponse, error)
+	GetOrder(orderID string) (*types.OpenOrder, error)
+	GetOpenOrders(params *types.OpenOrderParams, onlyFirstPage bool, nextCursor string) ([]types.OpenOrder, error)
+	GetTrades(params *types.TradeParams, onlyFirstPage bool, nextCursor string) ([]types.Trade, error)
+	CancelOrder(orderID string) (*types.CancelOrdersResponse, error)
+	CancelOrders(orderIDs []string) (*types.CancelOrdersResponse, error)
+	CancelAll() (*types.CancelOrdersResponse, error)
+	CancelMarketOrders(params *types.OrderMarketCancelParams) (*types.CancelOrdersResponse, error)
+}
+
+var (
+	_ Trader = (*ClobClient)(nil)
+	_ Trader = (*PaperTrader)(nil)
+)
+
+// NewTrader creates a live ClobClient or, when config.TradingMode is TradingModePaper,
+// a PaperTrader that reads market data through a ClobClient built from the same config.
+// Paper mode needs no key; a throwaway one is generated if none is configured.
+func NewTrader(config *ClientConfig) (Trader, error) {
+	switch config.TradingMode {
+	case "", TradingModeLive:
+		return NewClobClient(config)
+
+	case TradingModePaper:
+		marketConfig := *config
+		if marketConfig.Signer == nil && marketConfig.Keystore == nil && marketConfig.Mnemonic == nil && marketConfig.PrivateKey == "" {
+			wallet, err := auth.NewRandomWallet()
+			if err != nil {
+				return nil, fmt.Errorf("failed to create paper wallet: %w", err)
+			}
+			marketConfig.Signer = wallet.Signer()
+		}
+
+		marketData, err := NewClobClient(&marketConfig)
+		if err != nil {
+			return nil, err
+		}
+		return NewPaperTrader(marketData, config.Paper), nil
+
+	default:
+		return nil, fmt.Errorf("invalid trading mode: %s", config.TradingMode)
+	}
+}
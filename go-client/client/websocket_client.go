@@ -1,9 +1,14 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -12,27 +17,88 @@ import (
 )
 
 const (
-	wsURL        = "wss://ws-subscriptions-clob.polymarket.com"
-	pingInterval = 10 * time.Second
+	wsURL                    = "wss://ws-subscriptions-clob.polymarket.com"
+	defaultPingInterval      = 10 * time.Second
+	defaultPongTimeout       = 10 * time.Second
+	defaultReconnectDelay    = time.Second
+	defaultMaxReconnectDelay = 30 * time.Second
+	defaultReconnectJitter   = 0.2
+
+	// Frame buffers grown past this by a large book snapshot are dropped, not kept
+	maxRetainedFrameSize = 1 << 20
+)
+
+// WebSocketChannel selects the CLOB websocket channel
+type WebSocketChannel string
+
+const (
+	// WebSocketChannelMarket streams public book and trade events by asset ID
+	WebSocketChannelMarket WebSocketChannel = "market"
+	// WebSocketChannelUser streams the account's order and trade events by condition ID
+	WebSocketChannelUser WebSocketChannel = "user"
 )
 
 // WebSocketClientOptions configures the WebSocket client
 type WebSocketClientOptions struct {
-	// Asset IDs to subscribe to
+	// WebSocket base URL (default wss://ws-subscriptions-clob.polymarket.com)
+	URL string
+
+	// Channel to connect to (default market)
+	Channel WebSocketChannel
+
+	// Asset IDs to subscribe to on connect; change them later with Subscribe and Unsubscribe
 	AssetIDs []string
 
-	// Market condition IDs to subscribe to (for user channel)
+	// Market condition IDs to subscribe to (for user channel); Subscribe and Unsubscribe
+	// take condition IDs on the user channel
 	Markets []string
 
+	// APIKey authenticates the user channel. If nil, the ClobClient's credentials are
+	// used, created or derived once on the first connect.
+	APIKey *types.ApiKeyCreds
+
+	// CustomFeatures subscribes to the market channel's optional events: best_bid_ask,
+	// new_market and market_resolved
+	CustomFeatures bool
+
 	// Whether to auto-reconnect on disconnect
 	AutoReconnect bool
 
-	// Reconnection delay
+	// Delay before the first reconnection attempt, doubled after every failed attempt up
+	// to MaxReconnectDelay (default 1s)
 	ReconnectDelay time.Duration
 
-	// Maximum number of reconnection attempts (0 = infinite)
+	// Upper bound of the reconnection delay (default 30s)
+	MaxReconnectDelay time.Duration
+
+	// Fraction of each reconnection delay that is randomized, so many clients do not
+	// reconnect in lockstep (default 0.2, negative disables)
+	ReconnectJitter float64
+
+	// Maximum number of consecutive reconnection attempts (0 = infinite)
 	MaxReconnectAttempts int
 
+	// Interval between PINGs (default 10s)
+	PingInterval time.Duration
+
+	// How long after a missed PONG the connection is considered dead: the read deadline is
+	// PingInterval + PongTimeout after the last frame received (default 10s)
+	PongTimeout time.Duration
+
+	// StaleAfter enables the per-asset watchdog on the market channel: an asset without a
+	// message for this long is reported to OnStale with a REST order book (0 = disabled)
+	StaleAfter time.Duration
+
+	// ResubscribeStale resubscribes stale assets so the server resends their books
+	ResubscribeStale bool
+
+	// Recorder, if set, receives every frame except PONGs with its receive time
+	Recorder *SessionRecorder
+
+	// Decode tunes market channel decoding: skip validation or parse prices and sizes
+	// into the messages' numeric fields
+	Decode types.DecodeOptions
+
 	// Enable debug logging
 	Debug bool
 
@@ -40,7 +106,27 @@ type WebSocketClientOptions struct {
 	Logger *log.Logger
 }
 
-// MessageHandler is a callback function for handling messages
+// ConnectionState is the lifecycle state of a WebSocketClient
+type ConnectionState string
+
+const (
+	// StateIdle means not connected and not trying to: before Connect, after Disconnect or
+	// after reconnection gave up
+	StateIdle ConnectionState = "idle"
+	// StateConnecting means dialing and subscribing
+	StateConnecting ConnectionState = "connecting"
+	// StateConnected means the connection is up
+	StateConnected ConnectionState = "connected"
+	// StateBackingOff means waiting before the next reconnection attempt
+	StateBackingOff ConnectionState = "backing_off"
+	// StateClosed means Close was called; the client cannot connect again
+	StateClosed ConnectionState = "closed"
+)
+
+// ErrWebSocketClosed is returned by Connect after Close
+var ErrWebSocketClosed = errors.New("websocket client is closed")
+
+// MessageHandler is a callback function for handling messages of either channel
 type MessageHandler func(msg types.MarketChannelMessage)
 
 // BookMessageHandler handles book messages
@@ -55,34 +141,83 @@ type TickSizeChangeMessageHandler func(msg *types.TickSizeChangeMessage)
 // LastTradePriceMessageHandler handles last trade price messages
 type LastTradePriceMessageHandler func(msg *types.LastTradePriceMessage)
 
-// WebSocketCallbacks holds callback functions for different events
+// BestBidAskMessageHandler handles best bid/ask messages
+type BestBidAskMessageHandler func(msg *types.BestBidAskMessage)
+
+// NewMarketMessageHandler handles new market messages
+type NewMarketMessageHandler func(msg *types.NewMarketMessage)
+
+// MarketResolvedMessageHandler handles market resolution messages
+type MarketResolvedMessageHandler func(msg *types.MarketResolvedMessage)
+
+// OrderMessageHandler handles user channel order messages
+type OrderMessageHandler func(msg *types.OrderMessage)
+
+// TradeMessageHandler handles user channel trade messages
+type TradeMessageHandler func(msg *types.TradeMessage)
+
+// WebSocketCallbacks holds callback functions for different events. Message callbacks
+// run on the read goroutine, so a slow handler delays every message and the pings; use
+// Stream to consume messages from a buffered channel instead.
 type WebSocketCallbacks struct {
 	OnBook           BookMessageHandler
 	OnPriceChange    PriceChangeMessageHandler
 	OnTickSizeChange TickSizeChangeMessageHandler
 	OnLastTradePrice LastTradePriceMessageHandler
+	OnBestBidAsk     BestBidAskMessageHandler
+	OnNewMarket      NewMarketMessageHandler
+	OnMarketResolved MarketResolvedMessageHandler
+	OnOrder          OrderMessageHandler
+	OnTrade          TradeMessageHandler
 	OnMessage        MessageHandler
 	OnError          func(error)
 	OnConnect        func()
 	OnDisconnect     func(code int, reason string)
 	OnReconnect      func(attempt int)
+	OnStateChange    func(state ConnectionState)
+	OnStale          StaleEventHandler
 }
 
-// WebSocketClient manages WebSocket connections for market data
+// WebSocketClient manages WebSocket connections for market data.
+//
+// A session starts with Connect and runs until Disconnect, Close, a lost connection
+// without AutoReconnect, or MaxReconnectAttempts failed reconnections. Within a session
+// one goroutine reads the connection and reconnects with exponential backoff, one
+// goroutine per connection sends PINGs, and one runs the staleness watchdog if enabled;
+// all exit when the session ends.
 type WebSocketClient struct {
 	clobClient *ClobClient
 	options    *WebSocketClientOptions
 	callbacks  *WebSocketCallbacks
 
 	conn              *websocket.Conn
-	pingTicker        *time.Ticker
-	reconnectTimer    *time.Timer
+	writeMu           sync.Mutex
+	assets            *assetSet
+	creds             *types.ApiKeyCreds
+	state             ConnectionState
+	closed            bool
+	stop              chan struct{}
 	done              chan struct{}
 	reconnectAttempts int
-	isConnecting      bool
-	shouldReconnect   bool
 	mu                sync.RWMutex
 	logger            *log.Logger
+	streams           streamSet
+	watchdog          *assetWatchdog
+	watchers          sync.WaitGroup
+	frame             bytes.Buffer // owned by the read loop
+	protocol          *wsProtocol
+}
+
+// wsProtocol adapts the connection lifecycle to a websocket service other than the CLOB
+// channels, which are served when it is nil
+type wsProtocol struct {
+	// ping is sent every PingInterval and pong is the reply to drop
+	ping string
+	pong string
+	// subscription returns the message opening every connection, or nil for none
+	subscription func() interface{}
+	// handle receives every other text frame
+	handle func(data []byte)
 }
 
 // NewWebSocketClient creates a new WebSocket client
@@ -92,8 +227,26 @@ func NewWebSocketClient(clobClient *ClobClient, options *WebSocketClientOptions)
 	}
 
 	// Set defaults
-	if options.AutoReconnect && options.ReconnectDelay == 0 {
-		options.ReconnectDelay = 5 * time.Second
+	if options.URL == "" {
+		options.URL = wsURL
+	}
+	if options.Channel == "" {
+		options.Channel = WebSocketChannelMarket
+	}
+	if options.ReconnectDelay <= 0 {
+		options.ReconnectDelay = defaultReconnectDelay
+	}
+	if options.MaxReconnectDelay <= 0 {
+		options.MaxReconnectDelay = defaultMaxReconnectDelay
+	}
+	if options.ReconnectJitter == 0 {
+		options.ReconnectJitter = defaultReconnectJitter
+	}
+	if options.PingInterval <= 0 {
+		options.PingInterval = defaultPingInterval
+	}
+	if options.PongTimeout <= 0 {
+		options.PongTimeout = defaultPongTimeout
 	}
 
 	logger := options.Logger
@@ -101,13 +254,26 @@ func NewWebSocketClient(clobClient *ClobClient, options *WebSocketClientOptions)
 		logger = log.Default()
 	}
 
+	assets := newAssetSet()
+	if options.Channel == WebSocketChannelUser {
+		assets.add(options.Markets)
+	} else {
+		assets.add(options.AssetIDs)
+	}
+
+	// No session has run yet, so Wait and Done do not block
+	done := make(chan struct{})
+	close(done)
+
 	return &WebSocketClient{
-		clobClient:      clobClient,
-		options:         options,
-		callbacks:       &WebSocketCallbacks{},
-		done:            make(chan struct{}),
-		shouldReconnect: true,
-		logger:          logger,
+		clobClient: clobClient,
+		options:    options,
+		assets:     assets,
+		callbacks:  &WebSocketCallbacks{},
+		state:      StateIdle,
+		done:       done,
+		logger:     logger,
+		watchdog:   newAssetWatchdog(),
 	}
 }
 
@@ -117,113 +283,152 @@ func (ws *WebSocketClient) On(callbacks *WebSocketCallbacks) *WebSocketClient {
 	return ws
 }
 
-// Connect establishes the WebSocket connection
+// Connect establishes the WebSocket connection and starts a session. It returns the error
+// of the first dial; later connection losses are handled according to AutoReconnect.
 func (ws *WebSocketClient) Connect() error {
 	ws.mu.Lock()
-	if ws.isConnecting || (ws.conn != nil && ws.IsConnected()) {
+	if ws.closed {
+		ws.mu.Unlock()
+		return ErrWebSocketClosed
+	}
+	if ws.state != StateIdle {
 		ws.mu.Unlock()
 		ws.log("Already connected or connecting")
 		return nil
 	}
-	ws.isConnecting = true
-	ws.shouldReconnect = true
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	ws.stop = stop
+	ws.done = done
+	ws.reconnectAttempts = 0
+	ws.state = StateConnecting
 	ws.mu.Unlock()
+	ws.notifyState(StateConnecting)
 
-	// Derive API credentials
-	apiKey, err := ws.clobClient.DeriveApiKey(nil)
+	conn, err := ws.dial()
+	if err == nil {
+		err = ws.attach(conn, stop)
+	}
 	if err != nil {
-		ws.mu.Lock()
-		ws.isConnecting = false
-		ws.mu.Unlock()
-		return fmt.Errorf("failed to derive API key: %w", err)
+		ws.endSession(done)
+		return err
 	}
 
-	ws.log("API key derived:", apiKey.Key)
-
-	// Create WebSocket connection
-	fullURL := fmt.Sprintf("%s/ws/market", wsURL)
-	dialer := websocket.Dialer{}
-	conn, _, err := dialer.Dial(fullURL, nil)
-	if err != nil {
-		ws.mu.Lock()
-		ws.isConnecting = false
-		ws.mu.Unlock()
-		return fmt.Errorf("failed to connect to WebSocket: %w", err)
+	if ws.options.StaleAfter > 0 && ws.options.Channel == WebSocketChannelMarket {
+		ws.watchers.Add(1)
+		go ws.watch(stop)
 	}
+	go ws.run(conn, stop, done)
+	return nil
+}
 
+// Disconnect closes the connection and stops reconnecting. It does not wait for the
+// session to end; use Wait, Done or Close for that. Connect starts a new session.
+func (ws *WebSocketClient) Disconnect() {
 	ws.mu.Lock()
-	ws.conn = conn
-	ws.isConnecting = false
-	ws.reconnectAttempts = 0
+	if ws.stop != nil {
+		select {
+		case <-ws.stop:
+		default:
+			close(ws.stop)
+		}
+	}
+	conn := ws.conn
+	ws.conn = nil
 	ws.mu.Unlock()
 
-	ws.log("WebSocket connected")
-
-	// Send subscription message
-	if err := ws.sendSubscription(); err != nil {
-		return fmt.Errorf("failed to send subscription: %w", err)
+	if conn != nil {
+		ws.writeMu.Lock()
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+			time.Now().Add(time.Second))
+		ws.writeMu.Unlock()
+		conn.Close()
 	}
+}
+
+// Close disconnects and waits until the session's goroutines have exited or ctx is done.
+// The client cannot connect again afterwards. Do not call it from a callback, which runs
+// on the goroutine Close waits for.
+func (ws *WebSocketClient) Close(ctx context.Context) error {
+	ws.mu.Lock()
+	ws.closed = true
+	done := ws.done
+	ws.mu.Unlock()
 
-	// Start handlers
-	go ws.handleMessages()
-	go ws.pingLoop()
+	ws.Disconnect()
 
-	if ws.callbacks.OnConnect != nil {
-		ws.callbacks.OnConnect()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 
+	ws.setState(StateClosed)
+	ws.streams.close()
 	return nil
 }
 
-// Disconnect closes the WebSocket connection
-func (ws *WebSocketClient) Disconnect() {
-	ws.mu.Lock()
-	ws.shouldReconnect = false
-	ws.mu.Unlock()
-
-	ws.cleanup()
+// Done returns a channel that is closed when the current (or last) session ends
+func (ws *WebSocketClient) Done() <-chan struct{} {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
 
-	ws.mu.Lock()
-	if ws.conn != nil {
-		ws.conn.Close()
-		ws.conn = nil
-	}
-	ws.mu.Unlock()
+	return ws.done
 }
 
-// Subscribe adds asset IDs to the subscription
-func (ws *WebSocketClient) Subscribe(assetIDs []string) error {
-	ws.mu.Lock()
-	ws.options.AssetIDs = append(ws.options.AssetIDs, assetIDs...)
-	ws.mu.Unlock()
+// State returns the lifecycle state
+func (ws *WebSocketClient) State() ConnectionState {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+
+	return ws.state
+}
 
-	if ws.IsConnected() {
-		return ws.sendSubscription()
+// Subscribe adds asset IDs (condition IDs on the user channel) to the subscription.
+// Only IDs not already subscribed are sent, as an incremental subscribe operation on the
+// open connection.
+func (ws *WebSocketClient) Subscribe(ids []string) error {
+	added := ws.assets.add(ids)
+	if ws.options.Channel == WebSocketChannelMarket {
+		ws.watchdog.track(added, time.Now())
+	}
+	if len(added) == 0 || !ws.IsConnected() {
+		return nil
 	}
 
-	return nil
+	ws.log("Subscribing:", added)
+	return ws.writeJSON(map[string]interface{}{
+		ws.subscriptionKey(): added,
+		"operation":          "subscribe",
+	})
 }
 
-// Unsubscribe removes asset IDs from the subscription
-func (ws *WebSocketClient) Unsubscribe(assetIDs []string) {
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
-
-	// Filter out the asset IDs to unsubscribe
-	filtered := make([]string, 0, len(ws.options.AssetIDs))
-	for _, id := range ws.options.AssetIDs {
-		shouldKeep := true
-		for _, unsubID := range assetIDs {
-			if id == unsubID {
-				shouldKeep = false
-				break
-			}
-		}
-		if shouldKeep {
-			filtered = append(filtered, id)
-		}
+// Unsubscribe removes asset IDs (condition IDs on the user channel) from the subscription
+// and tells the server to stop sending their events
+func (ws *WebSocketClient) Unsubscribe(ids []string) error {
+	removed := ws.assets.remove(ids)
+	ws.watchdog.forget(removed)
+	if len(removed) == 0 || !ws.IsConnected() {
+		return nil
 	}
-	ws.options.AssetIDs = filtered
+
+	ws.log("Unsubscribing:", removed)
+	return ws.writeJSON(map[string]interface{}{
+		ws.subscriptionKey(): removed,
+		"operation":          "unsubscribe",
+	})
+}
+
+// Subscriptions returns the subscribed asset IDs (condition IDs on the user channel) in
+// subscription order; this is the set restored after a reconnect
+func (ws *WebSocketClient) Subscriptions() []string {
+	return ws.assets.list()
+}
+
+// IsSubscribed reports whether an asset ID (condition ID on the user channel) is subscribed
+func (ws *WebSocketClient) IsSubscribed(assetID string) bool {
+	return ws.assets.contains(assetID)
 }
 
 // IsConnected returns whether the WebSocket is connected
@@ -233,86 +438,412 @@ func (ws *WebSocketClient) IsConnected() bool {
 	return ws.conn != nil
 }
 
-// Wait blocks until the WebSocket is disconnected
+// Wait blocks until the current session ends
 func (ws *WebSocketClient) Wait() {
-	<-ws.done
+	<-ws.Done()
 }
 
+// sendSubscription sends the full subscription set; it opens every new connection
 func (ws *WebSocketClient) sendSubscription() error {
+	if ws.protocol != nil {
+		message := ws.protocol.subscription()
+		if message == nil {
+			return nil
+		}
+		ws.log("Sending subscription:", message)
+		return ws.writeJSON(message)
+	}
+
+	ids := ws.assets.list()
+
+	message := map[string]interface{}{
+		ws.subscriptionKey(): ids,
+		"type":               ws.options.Channel,
+	}
+	if ws.options.CustomFeatures && ws.options.Channel == WebSocketChannelMarket {
+		message["custom_feature_enabled"] = true
+	}
+
+	if ws.options.Channel == WebSocketChannelUser {
+		ws.mu.RLock()
+		creds := ws.creds
+		ws.mu.RUnlock()
+
+		message["auth"] = map[string]string{
+			"apiKey":     creds.Key,
+			"secret":     creds.Secret,
+			"passphrase": creds.Passphrase,
+		}
+	}
+
+	ws.log("Sending subscription:", ids)
+	return ws.writeJSON(message)
+}
+
+// subscriptionKey is the field that lists subscribed IDs on the channel
+func (ws *WebSocketClient) subscriptionKey() string {
+	if ws.options.Channel == WebSocketChannelUser {
+		return "markets"
+	}
+	return "assets_ids"
+}
+
+// resolveAPIKey picks the user channel credentials once, so reconnects reuse them
+func (ws *WebSocketClient) resolveAPIKey() error {
+	ws.mu.RLock()
+	resolved := ws.creds != nil
+	ws.mu.RUnlock()
+	if resolved {
+		return nil
+	}
+
+	creds := ws.options.APIKey
+	if creds == nil {
+		if ws.clobClient == nil {
+			return fmt.Errorf("user channel requires APIKey or a ClobClient")
+		}
+		var err error
+		if creds, err = ws.clobClient.EnsureApiKey(); err != nil {
+			return fmt.Errorf("failed to get API key for user channel: %w", err)
+		}
+	}
+
+	ws.mu.Lock()
+	ws.creds = creds
+	ws.mu.Unlock()
+	return nil
+}
+
+// writeJSON writes to the current connection; gorilla/websocket allows one writer at a time
+func (ws *WebSocketClient) writeJSON(v interface{}) error {
 	ws.mu.RLock()
 	conn := ws.conn
-	assetIDs := ws.options.AssetIDs
 	ws.mu.RUnlock()
 
 	if conn == nil {
 		return fmt.Errorf("not connected")
 	}
 
-	message := map[string]interface{}{
-		"assets_ids": assetIDs,
-		"type":       "market",
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	return conn.WriteJSON(v)
+}
+
+// writeText writes a text frame to conn
+func (ws *WebSocketClient) writeText(conn *websocket.Conn, text string) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, []byte(text))
+}
+
+// dial opens a connection. The user channel credentials are resolved on the first dial
+// only, so reconnects never create or derive keys.
+func (ws *WebSocketClient) dial() (*websocket.Conn, error) {
+	// The market channel is public; the user channel authenticates in its subscription
+	if ws.options.Channel == WebSocketChannelUser {
+		if err := ws.resolveAPIKey(); err != nil {
+			return nil, err
+		}
 	}
 
-	ws.log("Sending subscription:", assetIDs)
-	return conn.WriteJSON(message)
+	fullURL := fmt.Sprintf("%s/ws/%s", ws.options.URL, ws.options.Channel)
+	if ws.protocol != nil {
+		fullURL = ws.options.URL
+	}
+	dialer := websocket.Dialer{HandshakeTimeout: ws.options.PingInterval + ws.options.PongTimeout}
+	conn, _, err := dialer.Dial(fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to WebSocket: %w", err)
+	}
+	return conn, nil
 }
 
-func (ws *WebSocketClient) handleMessages() {
-	defer func() {
-		ws.log("Message handler stopped")
-		ws.handleDisconnect(websocket.CloseNormalClosure, "Connection closed")
-	}()
+// attach makes conn the current connection and subscribes on it, unless the session was
+// stopped while dialing
+func (ws *WebSocketClient) attach(conn *websocket.Conn, stop chan struct{}) error {
+	ws.mu.Lock()
+	select {
+	case <-stop:
+		ws.mu.Unlock()
+		conn.Close()
+		return fmt.Errorf("disconnected while connecting")
+	default:
+	}
+	ws.conn = conn
+	ws.reconnectAttempts = 0
+	ws.mu.Unlock()
 
-	for {
-		ws.mu.RLock()
-		conn := ws.conn
-		ws.mu.RUnlock()
+	conn.SetReadDeadline(time.Now().Add(ws.options.PingInterval + ws.options.PongTimeout))
+	if ws.options.Channel == WebSocketChannelMarket {
+		ws.watchdog.track(ws.assets.list(), time.Now())
+	}
+	if err := ws.sendSubscription(); err != nil {
+		ws.detach(conn)
+		return fmt.Errorf("failed to send subscription: %w", err)
+	}
 
-		if conn == nil {
+	ws.log("WebSocket connected")
+	ws.setState(StateConnected)
+	if ws.callbacks.OnConnect != nil {
+		ws.callbacks.OnConnect()
+	}
+	return nil
+}
+
+// detach forgets and closes conn
+func (ws *WebSocketClient) detach(conn *websocket.Conn) {
+	ws.mu.Lock()
+	if ws.conn == conn {
+		ws.conn = nil
+	}
+	ws.mu.Unlock()
+	conn.Close()
+}
+
+// run serves connections until the session ends
+func (ws *WebSocketClient) run(conn *websocket.Conn, stop chan struct{}, done chan struct{}) {
+	defer ws.endSession(done)
+
+	for conn != nil {
+		code, reason := ws.serve(conn, stop)
+		ws.detach(conn)
+
+		if ws.callbacks.OnDisconnect != nil {
+			ws.callbacks.OnDisconnect(code, reason)
+		}
+
+		select {
+		case <-stop:
 			return
+		default:
 		}
+		if !ws.options.AutoReconnect {
+			return
+		}
+		conn = ws.reconnect(stop)
+	}
+}
+
+// serve reads conn until it fails or the session stops
+func (ws *WebSocketClient) serve(conn *websocket.Conn, stop chan struct{}) (int, string) {
+	connDone := make(chan struct{})
+	defer close(connDone)
+	go ws.pingLoop(conn, stop, connDone)
 
-		messageType, message, err := conn.ReadMessage()
+	for {
+		messageType, reader, err := conn.NextReader()
+		if err == nil {
+			err = ws.readFrame(reader)
+		}
+		receivedAt := time.Now()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
-				ws.handleError(fmt.Errorf("WebSocket error: %w", err))
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+					ws.handleError(fmt.Errorf("WebSocket error: %w", err))
+				}
+				return closeErr.Code, closeErr.Text
 			}
-			return
+
+			select {
+			case <-stop:
+				return websocket.CloseNormalClosure, "Connection closed"
+			default:
+			}
+			var netErr interface{ Timeout() bool }
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				err = fmt.Errorf("no PONG within %v", ws.options.PingInterval+ws.options.PongTimeout)
+			}
+			ws.handleError(fmt.Errorf("WebSocket error: %w", err))
+			return websocket.CloseAbnormalClosure, err.Error()
 		}
 
+		// Any frame proves the connection alive; PONGs arrive at least every PingInterval
+		conn.SetReadDeadline(time.Now().Add(ws.options.PingInterval + ws.options.PongTimeout))
+
 		if messageType == websocket.TextMessage {
+			message := ws.frame.Bytes()
 			// Handle PONG
-			if string(message) == "PONG" {
+			if string(message) == ws.pongText() {
 				ws.log("Received PONG")
 				continue
 			}
 
+			if ws.options.Recorder != nil {
+				if err := ws.options.Recorder.Record(ws.options.Channel, message, receivedAt); err != nil {
+					ws.handleError(fmt.Errorf("failed to record frame: %w", err))
+				}
+			}
 			ws.processMessage(message)
 		}
 	}
 }
 
+// reconnect dials with exponential backoff until it succeeds, the attempts run out or the
+// session stops
+func (ws *WebSocketClient) reconnect(stop chan struct{}) *websocket.Conn {
+	for {
+		ws.mu.Lock()
+		if ws.options.MaxReconnectAttempts > 0 && ws.reconnectAttempts >= ws.options.MaxReconnectAttempts {
+			ws.mu.Unlock()
+			ws.log("Max reconnect attempts reached")
+			return nil
+		}
+		ws.reconnectAttempts++
+		attempt := ws.reconnectAttempts
+		ws.mu.Unlock()
+
+		delay := ws.backoff(attempt)
+		ws.log(fmt.Sprintf("Reconnect attempt %d in %v", attempt, delay))
+		ws.setState(StateBackingOff)
+		if ws.callbacks.OnReconnect != nil {
+			ws.callbacks.OnReconnect(attempt)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-stop:
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		ws.setState(StateConnecting)
+		conn, err := ws.dial()
+		if err == nil {
+			err = ws.attach(conn, stop)
+		}
+		if err != nil {
+			select {
+			case <-stop:
+				return nil
+			default:
+			}
+			ws.log("Reconnect failed:", err)
+			continue
+		}
+		return conn
+	}
+}
+
+// backoff returns the delay before a reconnection attempt: ReconnectDelay doubled per
+// attempt, capped at MaxReconnectDelay, with ReconnectJitter of it randomized
+func (ws *WebSocketClient) backoff(attempt int) time.Duration {
+	delay := ws.options.ReconnectDelay
+	for i := 1; i < attempt && delay < ws.options.MaxReconnectDelay; i++ {
+		delay *= 2
+	}
+	if delay > ws.options.MaxReconnectDelay {
+		delay = ws.options.MaxReconnectDelay
+	}
+	if jitter := ws.options.ReconnectJitter; jitter > 0 {
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+	return delay
+}
+
+// endSession stops the watchdog, returns to idle (or closed, which also closes the
+// streams) and signals Done
+func (ws *WebSocketClient) endSession(done chan struct{}) {
+	ws.mu.Lock()
+	select {
+	case <-ws.stop:
+	default:
+		close(ws.stop)
+	}
+	state := StateIdle
+	if ws.closed {
+		state = StateClosed
+	}
+	ws.mu.Unlock()
+	ws.watchers.Wait()
+
+	ws.setState(state)
+	if state == StateClosed {
+		ws.streams.close()
+	}
+	close(done)
+}
+
+func (ws *WebSocketClient) setState(state ConnectionState) {
+	ws.mu.Lock()
+	if ws.state == state || ws.state == StateClosed {
+		ws.mu.Unlock()
+		return
+	}
+	ws.state = state
+	ws.mu.Unlock()
+
+	ws.notifyState(state)
+}
+
+func (ws *WebSocketClient) notifyState(state ConnectionState) {
+	ws.log("State:", state)
+	if ws.callbacks.OnStateChange != nil {
+		ws.callbacks.OnStateChange(state)
+	}
+}
+
+// readFrame reads a frame into the reused frame buffer; decoded messages copy what they
+// keep, so the buffer is only valid until the next frame
+func (ws *WebSocketClient) readFrame(reader io.Reader) error {
+	ws.frame.Reset()
+	_, err := ws.frame.ReadFrom(reader)
+	if ws.frame.Cap() > maxRetainedFrameSize {
+		ws.frame = bytes.Buffer{}
+	}
+	return err
+}
+
+func (ws *WebSocketClient) pingText() string {
+	if ws.protocol != nil {
+		return ws.protocol.ping
+	}
+	return "PING"
+}
+
+func (ws *WebSocketClient) pongText() string {
+	if ws.protocol != nil {
+		return ws.protocol.pong
+	}
+	return "PONG"
+}
+
 func (ws *WebSocketClient) processMessage(data []byte) {
+	if ws.protocol != nil {
+		ws.protocol.handle(data)
+		return
+	}
+	if ws.options.Channel != WebSocketChannelUser {
+		messages, err := types.DecodeMarketChannelMessages(data, ws.options.Decode)
+		if err != nil {
+			ws.handleError(fmt.Errorf("failed to parse message: %w", err))
+			ws.log("Raw message:", string(data))
+		}
+		for _, msg := range messages {
+			ws.dispatch(msg)
+		}
+		return
+	}
+
 	// Try to parse as array first
 	var messages []json.RawMessage
 	if err := json.Unmarshal(data, &messages); err == nil {
 		// It's an array
 		for _, msgData := range messages {
-			ws.parseAndDispatch(msgData)
+			ws.parseAndDispatchUser(msgData)
 		}
 	} else {
 		// It's a single message
-		ws.parseAndDispatch(data)
+		ws.parseAndDispatchUser(data)
 	}
 }
 
-func (ws *WebSocketClient) parseAndDispatch(data []byte) {
-	msg, err := types.ParseMarketChannelMessage(data)
-	if err != nil {
-		ws.handleError(fmt.Errorf("failed to parse message: %w", err))
-		ws.log("Raw message:", string(data))
-		return
-	}
+func (ws *WebSocketClient) dispatch(msg types.MarketChannelMessage) {
+	ws.watchdog.seen(messageAssetIDs(msg), time.Now())
 
 	// Call specific handlers based on message type
 	switch msg.GetEventType() {
@@ -332,38 +863,72 @@ func (ws *WebSocketClient) parseAndDispatch(data []byte) {
 		if ltMsg, ok := types.AsLastTradePriceMessage(msg); ok && ws.callbacks.OnLastTradePrice != nil {
 			ws.callbacks.OnLastTradePrice(ltMsg)
 		}
+	case types.EventTypeBestBidAsk:
+		if bbaMsg, ok := types.AsBestBidAskMessage(msg); ok && ws.callbacks.OnBestBidAsk != nil {
+			ws.callbacks.OnBestBidAsk(bbaMsg)
+		}
+	case types.EventTypeNewMarket:
+		if nmMsg, ok := types.AsNewMarketMessage(msg); ok && ws.callbacks.OnNewMarket != nil {
+			ws.callbacks.OnNewMarket(nmMsg)
+		}
+	case types.EventTypeMarketResolved:
+		if mrMsg, ok := types.AsMarketResolvedMessage(msg); ok && ws.callbacks.OnMarketResolved != nil {
+			ws.callbacks.OnMarketResolved(mrMsg)
+		}
 	}
 
-	// Call general message handler
+	// Call general message handler; unknown event types arrive as *types.UnknownMessage
 	if ws.callbacks.OnMessage != nil {
 		ws.callbacks.OnMessage(msg)
 	}
+	ws.streams.publish(msg)
 }
 
-func (ws *WebSocketClient) pingLoop() {
-	ws.mu.Lock()
-	ws.pingTicker = time.NewTicker(pingInterval)
-	ticker := ws.pingTicker
-	ws.mu.Unlock()
+func (ws *WebSocketClient) parseAndDispatchUser(data []byte) {
+	msg, err := types.ParseUserChannelMessage(data)
+	if err != nil {
+		ws.handleError(fmt.Errorf("failed to parse message: %w", err))
+		ws.log("Raw message:", string(data))
+		return
+	}
 
+	switch msg.GetEventType() {
+	case types.EventTypeOrder:
+		if orderMsg, ok := types.AsOrderMessage(msg); ok && ws.callbacks.OnOrder != nil {
+			ws.callbacks.OnOrder(orderMsg)
+		}
+	case types.EventTypeTrade:
+		if tradeMsg, ok := types.AsTradeMessage(msg); ok && ws.callbacks.OnTrade != nil {
+			ws.callbacks.OnTrade(tradeMsg)
+		}
+	}
+
+	if ws.callbacks.OnMessage != nil {
+		ws.callbacks.OnMessage(msg)
+	}
+	ws.streams.publish(msg)
+}
+
+// pingLoop sends PINGs on conn until the connection ends, and closes conn when the
+// session stops so the blocked read returns
+func (ws *WebSocketClient) pingLoop(conn *websocket.Conn, stop chan struct{}, connDone chan struct{}) {
+	ticker := time.NewTicker(ws.options.PingInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ws.done:
+		case <-connDone:
+			return
+		case <-stop:
+			conn.Close()
 			return
 		case <-ticker.C:
-			ws.mu.RLock()
-			conn := ws.conn
-			ws.mu.RUnlock()
-
-			if conn != nil {
-				if err := conn.WriteMessage(websocket.TextMessage, []byte("PING")); err != nil {
-					ws.handleError(fmt.Errorf("failed to send ping: %w", err))
-					return
-				}
-				ws.log("Sent PING")
+			if err := ws.writeText(conn, ws.pingText()); err != nil {
+				ws.handleError(fmt.Errorf("failed to send ping: %w", err))
+				conn.Close()
+				return
 			}
+			ws.log("Sent PING")
 		}
 	}
 }
@@ -376,69 +941,79 @@ func (ws *WebSocketClient) handleError(err error) {
 	}
 }
 
-func (ws *WebSocketClient) handleDisconnect(code int, reason string) {
-	ws.cleanup()
-
-	if ws.callbacks.OnDisconnect != nil {
-		ws.callbacks.OnDisconnect(code, reason)
+func (ws *WebSocketClient) log(args ...interface{}) {
+	if ws.options.Debug {
+		ws.logger.Println(append([]interface{}{"[PolymarketWebSocket]"}, args...)...)
 	}
+}
 
-	ws.mu.RLock()
-	shouldReconnect := ws.shouldReconnect
-	autoReconnect := ws.options.AutoReconnect
-	ws.mu.RUnlock()
+// assetSet is an insertion-ordered set of asset IDs
+type assetSet struct {
+	mu    sync.Mutex
+	ids   []string
+	index map[string]int
+}
 
-	if shouldReconnect && autoReconnect {
-		ws.scheduleReconnect()
-	}
+func newAssetSet() *assetSet {
+	return &assetSet{index: make(map[string]int)}
 }
 
-func (ws *WebSocketClient) scheduleReconnect() {
-	ws.mu.Lock()
-	if ws.options.MaxReconnectAttempts > 0 && ws.reconnectAttempts >= ws.options.MaxReconnectAttempts {
-		ws.mu.Unlock()
-		ws.log("Max reconnect attempts reached")
-		return
-	}
+// add inserts the IDs and returns the ones that were not present, de-duplicated
+func (s *assetSet) add(ids []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	ws.reconnectAttempts++
-	attempt := ws.reconnectAttempts
-	delay := ws.options.ReconnectDelay
-	ws.mu.Unlock()
+	var added []string
+	for _, id := range ids {
+		if _, ok := s.index[id]; ok || id == "" {
+			continue
+		}
+		s.index[id] = len(s.ids)
+		s.ids = append(s.ids, id)
+		added = append(added, id)
+	}
+	return added
+}
 
-	ws.log(fmt.Sprintf("Scheduling reconnect attempt %d...", attempt))
+// remove deletes the IDs and returns the ones that were present
+func (s *assetSet) remove(ids []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if ws.callbacks.OnReconnect != nil {
-		ws.callbacks.OnReconnect(attempt)
+	var removed []string
+	for _, id := range ids {
+		if _, ok := s.index[id]; !ok {
+			continue
+		}
+		delete(s.index, id)
+		removed = append(removed, id)
+	}
+	if len(removed) == 0 {
+		return nil
 	}
 
-	ws.mu.Lock()
-	ws.reconnectTimer = time.AfterFunc(delay, func() {
-		ws.log(fmt.Sprintf("Attempting reconnect %d...", attempt))
-		if err := ws.Connect(); err != nil {
-			ws.log("Reconnect failed:", err)
+	kept := s.ids[:0]
+	for _, id := range s.ids {
+		if _, ok := s.index[id]; ok {
+			s.index[id] = len(kept)
+			kept = append(kept, id)
 		}
-	})
-	ws.mu.Unlock()
+	}
+	s.ids = kept
+	return removed
 }
 
-func (ws *WebSocketClient) cleanup() {
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
-
-	if ws.pingTicker != nil {
-		ws.pingTicker.Stop()
-		ws.pingTicker = nil
-	}
+func (s *assetSet) contains(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if ws.reconnectTimer != nil {
-		ws.reconnectTimer.Stop()
-		ws.reconnectTimer = nil
-	}
+	_, ok := s.index[id]
+	return ok
 }
 
-func (ws *WebSocketClient) log(args ...interface{}) {
-	if ws.options.Debug {
-		ws.logger.Println(append([]interface{}{"[PolymarketWebSocket]"}, args...)...)
-	}
+func (s *assetSet) list() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.ids...)
 }
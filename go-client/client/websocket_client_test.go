@@ -0,0 +1,260 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/clobtest"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+func TestMarketWebSocketSubscriptions(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	ws := client.NewWebSocketClient(clobClient, &client.WebSocketClientOptions{
+		URL:            srv.WebSocketURL(),
+		AssetIDs:       []string{"1", "1"},
+		AutoReconnect:  true,
+		ReconnectDelay: 10 * time.Millisecond,
+	})
+	connected := make(chan struct{}, 2)
+	ws.On(&client.WebSocketCallbacks{OnConnect: func() { connected <- struct{}{} }})
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer ws.Disconnect()
+
+	waitForSubscriptions(t, srv, "1")
+
+	if err := ws.Subscribe([]string{"2", "1", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	waitForSubscriptions(t, srv, "1", "2")
+
+	if err := ws.Unsubscribe([]string{"1"}); err != nil {
+		t.Fatal(err)
+	}
+	waitForSubscriptions(t, srv, "2")
+	if got := ws.Subscriptions(); len(got) != 1 || got[0] != "2" {
+		t.Fatalf("unexpected subscriptions: %v", got)
+	}
+
+	// The current set, not the initial one, is restored after a reconnect
+	<-connected
+	srv.DisconnectWebSockets()
+	select {
+	case <-connected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("client did not reconnect")
+	}
+	waitForSubscriptions(t, srv, "2")
+}
+
+func waitForSubscriptions(t *testing.T, srv *clobtest.Server, want ...string) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got := srv.Subscriptions()
+		if strings.Join(got, ",") == strings.Join(want, ",") && srv.WebSocketConnections() == 1 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server subscriptions = %v, want %v", got, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestUserWebSocket(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	events := make(chan types.UserChannelMessage, 8)
+	ws := client.NewWebSocketClient(clobClient, &client.WebSocketClientOptions{
+		URL:     srv.WebSocketURL(),
+		Channel: client.WebSocketChannelUser,
+		Markets: []string{"0xc0"},
+	})
+	ws.On(&client.WebSocketCallbacks{
+		OnOrder: func(msg *types.OrderMessage) { events <- msg },
+		OnTrade: func(msg *types.TradeMessage) { events <- msg },
+	})
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer ws.Disconnect()
+	waitForSubscriptions(t, srv, "0xc0")
+
+	resp, err := clobClient.CreateAndPostOrder(&types.UserOrder{TokenID: "1", Price: 0.5, Size: 10, Side: types.SideBuy}, nil, types.OrderTypeGTC)
+	if err != nil || !resp.Success {
+		t.Fatalf("CreateAndPostOrder: %v %+v", err, resp)
+	}
+	if msg := nextEvent(t, events).(*types.OrderMessage); msg.Type != types.OrderEventPlacement || msg.ID != resp.OrderID {
+		t.Fatalf("unexpected placement: %+v", msg)
+	}
+
+	trade, err := srv.FillOrder(resp.OrderID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := nextEvent(t, events).(*types.OrderMessage); msg.Type != types.OrderEventUpdate || msg.SizeMatched != "10" {
+		t.Fatalf("unexpected update: %+v", msg)
+	}
+	if msg := nextEvent(t, events).(*types.TradeMessage); msg.Status != types.TradeStatusMatched || msg.TakerOrderID != resp.OrderID {
+		t.Fatalf("unexpected trade: %+v", msg)
+	}
+
+	if err := srv.SetTradeStatus(trade.ID, types.TradeStatusConfirmed); err != nil {
+		t.Fatal(err)
+	}
+	if msg := nextEvent(t, events).(*types.TradeMessage); !msg.IsFinal() {
+		t.Fatalf("unexpected trade status: %+v", msg)
+	}
+}
+
+func nextEvent(t *testing.T, events chan types.UserChannelMessage) types.UserChannelMessage {
+	t.Helper()
+
+	select {
+	case msg := <-events:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("no user channel event received")
+		return nil
+	}
+}
+
+func TestWebSocketLifecycle(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	ws := client.NewWebSocketClient(clobClient, &client.WebSocketClientOptions{
+		URL:               srv.WebSocketURL(),
+		AssetIDs:          []string{"1"},
+		AutoReconnect:     true,
+		ReconnectDelay:    10 * time.Millisecond,
+		MaxReconnectDelay: 40 * time.Millisecond,
+		PingInterval:      20 * time.Millisecond,
+		PongTimeout:       30 * time.Millisecond,
+	})
+	states := make(chan client.ConnectionState, 64)
+	deadConnection := make(chan struct{}, 8)
+	ws.On(&client.WebSocketCallbacks{
+		OnStateChange: func(state client.ConnectionState) { states <- state },
+		OnError: func(err error) {
+			if strings.Contains(err.Error(), "no PONG") {
+				deadConnection <- struct{}{}
+			}
+		},
+	})
+
+	if ws.State() != client.StateIdle {
+		t.Fatalf("initial state %s", ws.State())
+	}
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	waitForState(t, states, client.StateConnected)
+
+	// A connection that stops answering PINGs is dropped and re-established
+	srv.SetPongs(false)
+	select {
+	case <-deadConnection:
+	case <-time.After(2 * time.Second):
+		t.Fatal("missing PONGs were not detected")
+	}
+	waitForState(t, states, client.StateBackingOff)
+	srv.SetPongs(true)
+	waitForState(t, states, client.StateConnected)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := ws.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case <-ws.Done():
+	default:
+		t.Fatal("Done not closed after Close")
+	}
+	if ws.State() != client.StateClosed {
+		t.Fatalf("state after Close %s", ws.State())
+	}
+	if err := ws.Connect(); !errors.Is(err, client.ErrWebSocketClosed) {
+		t.Fatalf("Connect after Close: %v", err)
+	}
+	waitFor(t, func() bool { return srv.WebSocketConnections() == 0 })
+}
+
+func waitForState(t *testing.T, states <-chan client.ConnectionState, want client.ConnectionState) {
+	t.Helper()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case state := <-states:
+			if state == want {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("state %s not reached", want)
+		}
+	}
+}
+
+func TestWebSocketNewAndUnknownEvents(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	ws := client.NewWebSocketClient(clobClient, &client.WebSocketClientOptions{
+		URL:            srv.WebSocketURL(),
+		AssetIDs:       []string{"1"},
+		CustomFeatures: true,
+	})
+	bestBidAsk := make(chan *types.BestBidAskMessage, 1)
+	unknown := make(chan *types.UnknownMessage, 1)
+	errs := make(chan error, 1)
+	ws.On(&client.WebSocketCallbacks{
+		OnBestBidAsk: func(msg *types.BestBidAskMessage) { bestBidAsk <- msg },
+		OnMessage: func(msg types.MarketChannelMessage) {
+			if m, ok := types.AsUnknownMessage(msg); ok {
+				unknown <- m
+			}
+		},
+		OnError: func(err error) { errs <- err },
+	})
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer ws.Disconnect()
+	waitForSubscriptions(t, srv, "1")
+
+	srv.PublishMarket("1", map[string]string{
+		"event_type": "best_bid_ask", "asset_id": "1", "market": "0xc0",
+		"best_bid": "0.48", "best_ask": "0.52", "spread": "0.04", "timestamp": "1",
+	})
+	srv.PublishMarket("1", map[string]interface{}{"event_type": "future_event", "asset_id": "1", "extra": 1})
+
+	select {
+	case msg := <-bestBidAsk:
+		if msg.BestBid != "0.48" || msg.Spread != "0.04" {
+			t.Fatalf("unexpected best_bid_ask: %+v", msg)
+		}
+	case err := <-errs:
+		t.Fatalf("OnError: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no best_bid_ask received")
+	}
+	select {
+	case msg := <-unknown:
+		if msg.EventType != "future_event" || !strings.Contains(string(msg.Raw), `"extra":1`) {
+			t.Fatalf("unexpected unknown message: %+v", msg)
+		}
+	case err := <-errs:
+		t.Fatalf("OnError: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no unknown message received")
+	}
+}
@@ -0,0 +1,496 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// defaultMaxAssetsPerConnection stays below the CLOB's per-connection subscription limit
+const defaultMaxAssetsPerConnection = 500
+
+// WebSocketPoolOptions configures a WebSocketPool
+type WebSocketPoolOptions struct {
+	// Client is the template for every shard's WebSocketClient (URL, Channel, APIKey,
+	// reconnect settings, logging). Its AssetIDs and Markets are ignored.
+	Client *WebSocketClientOptions
+
+	// IDs to subscribe to on connect: asset IDs on the market channel, condition IDs on the
+	// user channel
+	AssetIDs []string
+
+	// MaxAssetsPerConnection is the most IDs subscribed on one connection (default 500)
+	MaxAssetsPerConnection int
+}
+
+// ShardHealth reports the state of one pool connection
+type ShardHealth struct {
+	// ID identifies the shard for its lifetime; IDs are not reused
+	ID            int
+	Assets        int
+	Connected     bool
+	ConnectedAt   time.Time
+	LastMessageAt time.Time
+	Messages      uint64
+	Disconnects   int
+	Reconnects    int
+	LastError     error
+}
+
+// WebSocketPool spreads subscriptions over as many WebSocketClients as the per-connection
+// limit requires, so a large subscription is not one connection's problem. Subscribe fills
+// the least loaded shard with room and opens new shards when all are full; Unsubscribe
+// closes shards that are no longer needed, moving their remaining IDs to the others.
+//
+// Events of all shards arrive on the callbacks registered with On and on Stream. Callbacks
+// of different shards run concurrently.
+type WebSocketPool struct {
+	clobClient *ClobClient
+	options    *WebSocketPoolOptions
+
+	mu        sync.Mutex
+	callbacks *WebSocketCallbacks
+	shards    []*poolShard
+	placement map[string]*poolShard
+	nextID    int
+	running   bool
+	closed    bool
+
+	streams streamSet
+}
+
+type poolShard struct {
+	id     int
+	client *WebSocketClient
+	assets map[string]bool
+
+	mu            sync.Mutex
+	connectedAt   time.Time
+	lastMessageAt time.Time
+	messages      uint64
+	disconnects   int
+	reconnects    int
+	lastError     error
+}
+
+// NewWebSocketPool creates a pool; call Connect to open its connections
+func NewWebSocketPool(clobClient *ClobClient, options *WebSocketPoolOptions) (*WebSocketPool, error) {
+	if options == nil {
+		options = &WebSocketPoolOptions{}
+	}
+	if options.Client == nil {
+		options.Client = &WebSocketClientOptions{}
+	}
+	if options.MaxAssetsPerConnection <= 0 {
+		options.MaxAssetsPerConnection = defaultMaxAssetsPerConnection
+	}
+
+	pool := &WebSocketPool{
+		clobClient: clobClient,
+		options:    options,
+		callbacks:  &WebSocketCallbacks{},
+		placement:  make(map[string]*poolShard),
+	}
+	if err := pool.Subscribe(options.AssetIDs); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// On registers the event handlers shared by all shards
+func (p *WebSocketPool) On(callbacks *WebSocketCallbacks) *WebSocketPool {
+	p.mu.Lock()
+	p.callbacks = callbacks
+	p.mu.Unlock()
+	return p
+}
+
+// Stream returns a new MessageStream receiving the messages of every shard. Streams
+// outlive Disconnect; Close closes them.
+func (p *WebSocketPool) Stream(options *StreamOptions) *MessageStream {
+	return p.streams.open(options)
+}
+
+// Connect opens every shard's connection. Shards created later by Subscribe connect
+// on their own.
+func (p *WebSocketPool) Connect() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrWebSocketClosed
+	}
+	p.running = true
+	shards := append([]*poolShard{}, p.shards...)
+	p.mu.Unlock()
+
+	var errs []error
+	for _, shard := range shards {
+		if err := shard.client.Connect(); err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", shard.id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Disconnect closes every shard's connection. Connect opens them again.
+func (p *WebSocketPool) Disconnect() {
+	p.mu.Lock()
+	p.running = false
+	shards := append([]*poolShard{}, p.shards...)
+	p.mu.Unlock()
+
+	for _, shard := range shards {
+		shard.client.Disconnect()
+	}
+}
+
+// Close closes every shard, waits for their sessions to end or ctx to be done, and
+// closes the streams. The pool cannot connect again afterwards.
+func (p *WebSocketPool) Close(ctx context.Context) error {
+	p.mu.Lock()
+	p.running = false
+	p.closed = true
+	shards := append([]*poolShard{}, p.shards...)
+	p.mu.Unlock()
+
+	var errs []error
+	for _, shard := range shards {
+		if err := shard.client.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", shard.id, err))
+		}
+	}
+	p.streams.close()
+	return errors.Join(errs...)
+}
+
+// Subscribe adds IDs to the least loaded shards with room, opening shards as needed
+func (p *WebSocketPool) Subscribe(ids []string) error {
+	p.mu.Lock()
+	added := make(map[*poolShard][]string)
+	var created []*poolShard
+	for _, id := range ids {
+		if _, ok := p.placement[id]; ok || id == "" {
+			continue
+		}
+		shard := p.shardWithRoom()
+		if shard == nil {
+			shard = p.newShard()
+			created = append(created, shard)
+		}
+		shard.assets[id] = true
+		p.placement[id] = shard
+		added[shard] = append(added[shard], id)
+	}
+	running := p.running
+	p.mu.Unlock()
+
+	var errs []error
+	for _, shard := range sortShards(added) {
+		if err := shard.client.Subscribe(added[shard]); err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", shard.id, err))
+		}
+	}
+	if running {
+		for _, shard := range created {
+			if err := shard.client.Connect(); err != nil {
+				errs = append(errs, fmt.Errorf("shard %d: %w", shard.id, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Unsubscribe removes IDs and closes the shards the remaining IDs no longer need. The IDs
+// of a closed shard are subscribed on the others before it disconnects, so they may see
+// a few duplicate events but no gap.
+func (p *WebSocketPool) Unsubscribe(ids []string) error {
+	p.mu.Lock()
+	removed := make(map[*poolShard][]string)
+	for _, id := range ids {
+		shard, ok := p.placement[id]
+		if !ok {
+			continue
+		}
+		delete(p.placement, id)
+		delete(shard.assets, id)
+		removed[shard] = append(removed[shard], id)
+	}
+
+	// Close the least loaded shards while the others can take their IDs
+	moved := make(map[*poolShard][]string)
+	var closed, created []*poolShard
+	limit := p.options.MaxAssetsPerConnection
+	needed := (len(p.placement) + limit - 1) / limit
+	for len(p.shards) > needed {
+		victim := p.shards[0]
+		for _, shard := range p.shards {
+			if len(shard.assets) < len(victim.assets) {
+				victim = shard
+			}
+		}
+		p.removeShard(victim)
+		closed = append(closed, victim)
+
+		for _, id := range sortedKeys(victim.assets) {
+			target := p.shardWithRoom()
+			if target == nil {
+				target = p.newShard()
+				created = append(created, target)
+			}
+			target.assets[id] = true
+			p.placement[id] = target
+			moved[target] = append(moved[target], id)
+		}
+	}
+	running := p.running
+	p.mu.Unlock()
+
+	var errs []error
+	for _, shard := range sortShards(moved) {
+		if err := shard.client.Subscribe(moved[shard]); err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", shard.id, err))
+		}
+	}
+	if running {
+		for _, shard := range created {
+			if err := shard.client.Connect(); err != nil {
+				errs = append(errs, fmt.Errorf("shard %d: %w", shard.id, err))
+			}
+		}
+	}
+	for _, shard := range closed {
+		shard.client.Disconnect()
+		delete(removed, shard)
+	}
+	for _, shard := range sortShards(removed) {
+		if err := shard.client.Unsubscribe(removed[shard]); err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", shard.id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Subscriptions returns every subscribed ID, sorted
+func (p *WebSocketPool) Subscriptions() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := make([]string, 0, len(p.placement))
+	for id := range p.placement {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Shards returns the number of shards
+func (p *WebSocketPool) Shards() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.shards)
+}
+
+// Health reports every shard, in creation order
+func (p *WebSocketPool) Health() []ShardHealth {
+	p.mu.Lock()
+	shards := append([]*poolShard{}, p.shards...)
+	assets := make([]int, len(shards))
+	for i, shard := range shards {
+		assets[i] = len(shard.assets)
+	}
+	p.mu.Unlock()
+
+	health := make([]ShardHealth, len(shards))
+	for i, shard := range shards {
+		shard.mu.Lock()
+		health[i] = ShardHealth{
+			ID:            shard.id,
+			Assets:        assets[i],
+			Connected:     shard.client.IsConnected(),
+			ConnectedAt:   shard.connectedAt,
+			LastMessageAt: shard.lastMessageAt,
+			Messages:      shard.messages,
+			Disconnects:   shard.disconnects,
+			Reconnects:    shard.reconnects,
+			LastError:     shard.lastError,
+		}
+		shard.mu.Unlock()
+	}
+	return health
+}
+
+// shardWithRoom returns the least loaded shard below the limit. The caller must hold p.mu.
+func (p *WebSocketPool) shardWithRoom() *poolShard {
+	var best *poolShard
+	for _, shard := range p.shards {
+		if len(shard.assets) >= p.options.MaxAssetsPerConnection {
+			continue
+		}
+		if best == nil || len(shard.assets) < len(best.assets) {
+			best = shard
+		}
+	}
+	return best
+}
+
+// newShard adds an unconnected shard. The caller must hold p.mu.
+func (p *WebSocketPool) newShard() *poolShard {
+	options := *p.options.Client
+	options.AssetIDs = nil
+	options.Markets = nil
+
+	shard := &poolShard{
+		id:     p.nextID,
+		client: NewWebSocketClient(p.clobClient, &options),
+		assets: make(map[string]bool),
+	}
+	p.nextID++
+	shard.client.On(p.shardCallbacks(shard))
+	p.shards = append(p.shards, shard)
+	return shard
+}
+
+// removeShard drops a shard from the pool. The caller must hold p.mu.
+func (p *WebSocketPool) removeShard(shard *poolShard) {
+	for i, s := range p.shards {
+		if s == shard {
+			p.shards = append(p.shards[:i], p.shards[i+1:]...)
+			return
+		}
+	}
+}
+
+func (p *WebSocketPool) currentCallbacks() *WebSocketCallbacks {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.callbacks
+}
+
+// shardCallbacks records a shard's health and forwards its events to the pool callbacks
+func (p *WebSocketPool) shardCallbacks(shard *poolShard) *WebSocketCallbacks {
+	return &WebSocketCallbacks{
+		OnBook: func(msg *types.BookMessage) {
+			if cb := p.currentCallbacks().OnBook; cb != nil {
+				cb(msg)
+			}
+		},
+		OnPriceChange: func(msg *types.PriceChangeMessage) {
+			if cb := p.currentCallbacks().OnPriceChange; cb != nil {
+				cb(msg)
+			}
+		},
+		OnTickSizeChange: func(msg *types.TickSizeChangeMessage) {
+			if cb := p.currentCallbacks().OnTickSizeChange; cb != nil {
+				cb(msg)
+			}
+		},
+		OnLastTradePrice: func(msg *types.LastTradePriceMessage) {
+			if cb := p.currentCallbacks().OnLastTradePrice; cb != nil {
+				cb(msg)
+			}
+		},
+		OnBestBidAsk: func(msg *types.BestBidAskMessage) {
+			if cb := p.currentCallbacks().OnBestBidAsk; cb != nil {
+				cb(msg)
+			}
+		},
+		OnNewMarket: func(msg *types.NewMarketMessage) {
+			if cb := p.currentCallbacks().OnNewMarket; cb != nil {
+				cb(msg)
+			}
+		},
+		OnMarketResolved: func(msg *types.MarketResolvedMessage) {
+			if cb := p.currentCallbacks().OnMarketResolved; cb != nil {
+				cb(msg)
+			}
+		},
+		OnOrder: func(msg *types.OrderMessage) {
+			if cb := p.currentCallbacks().OnOrder; cb != nil {
+				cb(msg)
+			}
+		},
+		OnTrade: func(msg *types.TradeMessage) {
+			if cb := p.currentCallbacks().OnTrade; cb != nil {
+				cb(msg)
+			}
+		},
+		OnMessage: func(msg types.MarketChannelMessage) {
+			shard.mu.Lock()
+			shard.lastMessageAt = time.Now()
+			shard.messages++
+			shard.mu.Unlock()
+
+			if cb := p.currentCallbacks().OnMessage; cb != nil {
+				cb(msg)
+			}
+			p.streams.publish(msg)
+		},
+		OnStale: func(event *StaleEvent) {
+			if cb := p.currentCallbacks().OnStale; cb != nil {
+				cb(event)
+			}
+		},
+		OnError: func(err error) {
+			shard.mu.Lock()
+			shard.lastError = err
+			shard.mu.Unlock()
+
+			if cb := p.currentCallbacks().OnError; cb != nil {
+				cb(fmt.Errorf("shard %d: %w", shard.id, err))
+			}
+		},
+		OnConnect: func() {
+			shard.mu.Lock()
+			shard.connectedAt = time.Now()
+			shard.mu.Unlock()
+
+			if cb := p.currentCallbacks().OnConnect; cb != nil {
+				cb()
+			}
+		},
+		OnDisconnect: func(code int, reason string) {
+			shard.mu.Lock()
+			shard.disconnects++
+			shard.mu.Unlock()
+
+			if cb := p.currentCallbacks().OnDisconnect; cb != nil {
+				cb(code, reason)
+			}
+		},
+		OnReconnect: func(attempt int) {
+			shard.mu.Lock()
+			shard.reconnects++
+			shard.mu.Unlock()
+
+			if cb := p.currentCallbacks().OnReconnect; cb != nil {
+				cb(attempt)
+			}
+		},
+	}
+}
+
+// sortShards returns the shards of a plan in creation order
+func sortShards(plan map[*poolShard][]string) []*poolShard {
+	shards := make([]*poolShard, 0, len(plan))
+	for shard := range plan {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i].id < shards[j].id })
+	return shards
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
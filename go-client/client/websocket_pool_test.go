@@ -0,0 +1,107 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+func TestWebSocketPool(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	pool, err := client.NewWebSocketPool(clobClient, &client.WebSocketPoolOptions{
+		Client:                 &client.WebSocketClientOptions{URL: srv.WebSocketURL()},
+		AssetIDs:               []string{"1", "2", "3"},
+		MaxAssetsPerConnection: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	books := pool.Stream(&client.StreamOptions{EventTypes: []types.EventType{types.EventTypeBook}})
+	if err := pool.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer pool.Disconnect()
+
+	if pool.Shards() != 2 {
+		t.Fatalf("shards = %d, want 2", pool.Shards())
+	}
+	waitFor(t, func() bool {
+		return srv.WebSocketConnections() == 2 && strings.Join(srv.Subscriptions(), ",") == "1,2,3"
+	})
+	if got := receiveBid(t, books.C()); got != "0.48" {
+		t.Fatalf("unexpected book bid %s", got)
+	}
+
+	// Dropping to two IDs needs one connection; the remaining ID of the closed shard moves
+	if err := pool.Unsubscribe([]string{"2"}); err != nil {
+		t.Fatal(err)
+	}
+	waitForSubscriptions(t, srv, "1", "3")
+	health := pool.Health()
+	if len(health) != 1 || health[0].Assets != 2 || !health[0].Connected {
+		t.Fatalf("unexpected health: %+v", health)
+	}
+
+	// Growing past the limit opens a new shard
+	if err := pool.Subscribe([]string{"4"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		return srv.WebSocketConnections() == 2 && strings.Join(srv.Subscriptions(), ",") == "1,3,4"
+	})
+
+	// Streams stay open across Disconnect and receive the books resent on reconnecting
+	pool.Disconnect()
+	waitFor(t, func() bool { return srv.WebSocketConnections() == 0 })
+	for len(books.C()) > 0 {
+		<-books.C()
+	}
+	if err := pool.Connect(); err != nil {
+		t.Fatalf("Connect after Disconnect: %v", err)
+	}
+	receiveBook(t, books.C(), "1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := pool.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	waitFor(t, func() bool { return srv.WebSocketConnections() == 0 })
+	select {
+	case _, ok := <-books.C():
+		for ok {
+			_, ok = <-books.C()
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream not closed by Close")
+	}
+	if err := pool.Connect(); !errors.Is(err, client.ErrWebSocketClosed) {
+		t.Fatalf("Connect after Close: %v", err)
+	}
+}
+
+// receiveBook waits for a book message of assetID, skipping others
+func receiveBook(t *testing.T, ch <-chan types.MarketChannelMessage, assetID string) {
+	t.Helper()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				t.Fatal("stream closed")
+			}
+			if book, isBook := types.AsBookMessage(msg); isBook && book.AssetID == assetID {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no book for %s received", assetID)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package client
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRecorderPrefix      = "ws"
+	defaultRecorderMaxFileSize = 64 << 20
+	defaultRecorderMaxFileAge  = time.Hour
+	recorderFileSuffix         = ".jsonl.gz"
+)
+
+// RecordedFrame is one websocket frame as received, one JSON line in a session file
+type RecordedFrame struct {
+	// Time the frame was read from the connection
+	Time    time.Time        `json:"t"`
+	Channel WebSocketChannel `json:"channel"`
+	// Frame is the raw text frame, byte for byte
+	Frame string `json:"frame"`
+}
+
+// SessionRecorderConfig configures a SessionRecorder
+type SessionRecorderConfig struct {
+	// Dir receives the session files (created if missing)
+	Dir string
+
+	// Prefix of the file names (default "ws"); files are named
+	// <prefix>-<UTC start time>-<sequence>.jsonl.gz so they sort chronologically
+	Prefix string
+
+	// MaxFileSize rotates a file after this many uncompressed bytes (default 64 MiB)
+	MaxFileSize int64
+
+	// MaxFileAge rotates a file after it has been open this long (default 1h)
+	MaxFileAge time.Duration
+}
+
+// SessionRecorder writes every websocket frame with its receive time to rotating gzip
+// compressed JSONL files. Set it as WebSocketClientOptions.Recorder; one recorder may be
+// shared by several clients (e.g. a market and a user channel, or a WebSocketPool).
+type SessionRecorder struct {
+	config *SessionRecorderConfig
+
+	mu       sync.Mutex
+	file     *os.File
+	gz       *gzip.Writer
+	buf      *bufio.Writer
+	size     int64
+	openedAt time.Time
+	sequence int
+	files    []string
+	closed   bool
+}
+
+// NewSessionRecorder creates a recorder; the first file is opened on the first frame
+func NewSessionRecorder(config *SessionRecorderConfig) (*SessionRecorder, error) {
+	if config == nil || config.Dir == "" {
+		return nil, fmt.Errorf("session recorder requires a directory")
+	}
+	if config.Prefix == "" {
+		config.Prefix = defaultRecorderPrefix
+	}
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = defaultRecorderMaxFileSize
+	}
+	if config.MaxFileAge <= 0 {
+		config.MaxFileAge = defaultRecorderMaxFileAge
+	}
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	return &SessionRecorder{config: config}, nil
+}
+
+// Record appends a frame
+func (r *SessionRecorder) Record(channel WebSocketChannel, frame []byte, receivedAt time.Time) error {
+	line, err := json.Marshal(RecordedFrame{Time: receivedAt.UTC(), Channel: channel, Frame: string(frame)})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return fmt.Errorf("session recorder is closed")
+	}
+	if r.file != nil && (r.size >= r.config.MaxFileSize || time.Since(r.openedAt) >= r.config.MaxFileAge) {
+		if err := r.closeFile(); err != nil {
+			return err
+		}
+	}
+	if r.file == nil {
+		if err := r.openFile(); err != nil {
+			return err
+		}
+	}
+
+	n, err := r.buf.Write(line)
+	r.size += int64(n)
+	return err
+}
+
+// Flush writes buffered frames through to the current file
+func (r *SessionRecorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	if err := r.buf.Flush(); err != nil {
+		return err
+	}
+	return r.gz.Flush()
+}
+
+// Files returns the files written so far, oldest first
+func (r *SessionRecorder) Files() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string{}, r.files...)
+}
+
+// Close finishes the current file
+func (r *SessionRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	if r.file == nil {
+		return nil
+	}
+	return r.closeFile()
+}
+
+// openFile starts a new file. The caller must hold r.mu.
+func (r *SessionRecorder) openFile() error {
+	now := time.Now().UTC()
+	r.sequence++
+	name := fmt.Sprintf("%s-%s-%06d%s", r.config.Prefix, now.Format("20060102T150405.000Z"), r.sequence, recorderFileSuffix)
+	path := filepath.Join(r.config.Dir, name)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create session file: %w", err)
+	}
+
+	r.file = file
+	r.gz = gzip.NewWriter(file)
+	r.buf = bufio.NewWriter(r.gz)
+	r.size = 0
+	r.openedAt = now
+	r.files = append(r.files, path)
+	return nil
+}
+
+// closeFile flushes and closes the current file. The caller must hold r.mu.
+func (r *SessionRecorder) closeFile() error {
+	err := r.buf.Flush()
+	if closeErr := r.gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := r.file.Close(); err == nil {
+		err = closeErr
+	}
+	r.file, r.gz, r.buf = nil, nil, nil
+	if err != nil {
+		return fmt.Errorf("failed to finish session file: %w", err)
+	}
+	return nil
+}
+
+// SessionFiles lists the session files in dir written with prefix (default "ws"), oldest
+// first
+func SessionFiles(dir string, prefix string) ([]string, error) {
+	if prefix == "" {
+		prefix = defaultRecorderPrefix
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, prefix+"-*"+recorderFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// SessionReader reads recorded frames from session files in order. Plain .jsonl files
+// are read as well as compressed ones.
+type SessionReader struct {
+	paths   []string
+	file    *os.File
+	gz      *gzip.Reader
+	decoder *json.Decoder
+}
+
+// OpenSession reads the given files one after another
+func OpenSession(paths ...string) (*SessionReader, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no session files")
+	}
+	return &SessionReader{paths: paths}, nil
+}
+
+// Next returns the next frame, or io.EOF after the last file
+func (s *SessionReader) Next() (*RecordedFrame, error) {
+	for {
+		if s.decoder == nil {
+			if len(s.paths) == 0 {
+				return nil, io.EOF
+			}
+			if err := s.open(s.paths[0]); err != nil {
+				return nil, err
+			}
+			s.paths = s.paths[1:]
+		}
+
+		var frame RecordedFrame
+		err := s.decoder.Decode(&frame)
+		if err == nil {
+			return &frame, nil
+		}
+		name := s.file.Name()
+		s.closeFile()
+		// A file cut short by a crash ends the file, not the session
+		if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+	}
+}
+
+// Close releases the current file
+func (s *SessionReader) Close() error {
+	s.paths = nil
+	s.closeFile()
+	return nil
+}
+
+func (s *SessionReader) open(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		s.gz = gz
+		reader = gz
+	}
+	s.file = file
+	s.decoder = json.NewDecoder(bufio.NewReader(reader))
+	return nil
+}
+
+func (s *SessionReader) closeFile() {
+	if s.gz != nil {
+		s.gz.Close()
+	}
+	if s.file != nil {
+		s.file.Close()
+	}
+	s.file, s.gz, s.decoder = nil, nil, nil
+}
+
+// ReplayOptions configures WebSocketClient.Replay
+type ReplayOptions struct {
+	// Speed multiplies the recorded pace: 1 replays in real time, 10 ten times faster and
+	// 0 as fast as possible
+	Speed float64
+
+	// From and To limit the replay to frames received in [From, To) (zero = unbounded)
+	From time.Time
+	To   time.Time
+}
+
+// Replay feeds recorded frames of the client's channel through the same parsing and
+// dispatch as live frames: callbacks and streams see exactly what was received. The
+// staleness watchdog runs on wall-clock time and does not follow the recorded times.
+// It runs on the calling goroutine and returns the number of frames replayed. The
+// client does not need to be connected.
+func (ws *WebSocketClient) Replay(ctx context.Context, session *SessionReader, options *ReplayOptions) (int, error) {
+	if options == nil {
+		options = &ReplayOptions{}
+	}
+
+	var firstFrame time.Time
+	var started time.Time
+	count := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		frame, err := session.Next()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+
+		if frame.Channel != ws.options.Channel {
+			continue
+		}
+		if !options.From.IsZero() && frame.Time.Before(options.From) {
+			continue
+		}
+		if !options.To.IsZero() && !frame.Time.Before(options.To) {
+			return count, nil
+		}
+
+		if options.Speed > 0 {
+			if firstFrame.IsZero() {
+				firstFrame, started = frame.Time, time.Now()
+			}
+			due := started.Add(time.Duration(float64(frame.Time.Sub(firstFrame)) / options.Speed))
+			if wait := time.Until(due); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return count, ctx.Err()
+				case <-timer.C:
+				}
+			}
+		}
+
+		ws.processMessage([]byte(frame.Frame))
+		count++
+	}
+}
@@ -0,0 +1,65 @@
+package client_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+func TestWebSocketRecordReplay(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	dir := t.TempDir()
+	recorder, err := client.NewSessionRecorder(&client.SessionRecorderConfig{Dir: dir, MaxFileSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws := client.NewWebSocketClient(clobClient, &client.WebSocketClientOptions{
+		URL:      srv.WebSocketURL(),
+		AssetIDs: []string{"1"},
+		Recorder: recorder,
+	})
+	live := ws.Stream(nil)
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	var want []string
+	want = append(want, receiveBid(t, live.C()))
+	for _, bid := range []string{"0.45", "0.46"} {
+		srv.SetBook("1", []types.OrderSummary{{Price: bid, Size: "1"}}, nil)
+		srv.PublishBook("1")
+		want = append(want, receiveBid(t, live.C()))
+	}
+	ws.Disconnect()
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every frame exceeded MaxFileSize, so each went to its own file
+	files, err := client.SessionFiles(dir, "")
+	if err != nil || len(files) != 3 {
+		t.Fatalf("session files = %v, %v", files, err)
+	}
+
+	session, err := client.OpenSession(files...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	var got []string
+	replay := client.NewWebSocketClient(nil, nil)
+	replay.On(&client.WebSocketCallbacks{
+		OnBook: func(msg *types.BookMessage) { got = append(got, msg.Bids[0].Price) },
+	})
+	count, err := replay.Replay(context.Background(), session, &client.ReplayOptions{Speed: 100})
+	if err != nil || count != 3 {
+		t.Fatalf("Replay = %d, %v", count, err)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("replayed %v, recorded %v", got, want)
+	}
+}
@@ -0,0 +1,301 @@
+package client
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+const defaultStreamBufferSize = 256
+
+// OverflowPolicy decides what a MessageStream does when its buffer is full
+type OverflowPolicy string
+
+const (
+	// OverflowBlock makes the websocket read loop wait for the consumer. Nothing is lost,
+	// but a stalled consumer stalls the connection (and every other stream).
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropOldest discards the oldest buffered message to make room
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowDropNewest discards the incoming message
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+	// OverflowCoalesce keeps only the latest buffered message per event type and asset: a
+	// new message replaces the pending one with the same key. When the buffer is full of
+	// distinct keys the oldest is dropped. Coalesced price_change messages lose deltas, so
+	// this suits consumers that only need the latest state.
+	OverflowCoalesce OverflowPolicy = "coalesce"
+)
+
+// StreamOptions configures a MessageStream
+type StreamOptions struct {
+	// BufferSize is the number of messages buffered for the consumer (default 256)
+	BufferSize int
+
+	// Overflow is the policy applied when the buffer is full (default OverflowBlock)
+	Overflow OverflowPolicy
+
+	// EventTypes limits the stream to these event types (default all)
+	EventTypes []types.EventType
+}
+
+// StreamStats counts what happened to the messages offered to a MessageStream
+type StreamStats struct {
+	// Delivered messages were received by the consumer
+	Delivered uint64
+	// Dropped messages were discarded by OverflowDropOldest, OverflowDropNewest or a full
+	// OverflowCoalesce buffer
+	Dropped uint64
+	// Coalesced messages were replaced by a newer message for the same key
+	Coalesced uint64
+	// Queued is the number of messages currently buffered
+	Queued int
+}
+
+// MessageStream delivers websocket messages on a channel, decoupled from the read loop by
+// a bounded buffer. Create one per consumer with WebSocketClient.Stream
+// or WebSocketPool.Stream.
+type MessageStream struct {
+	options    StreamOptions
+	eventTypes map[types.EventType]bool
+	detach     func(*MessageStream)
+
+	out  chan types.MarketChannelMessage
+	done chan struct{}
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []*streamItem
+	keys   map[string]*streamItem
+	closed bool
+	stats  StreamStats
+}
+
+type streamItem struct {
+	key string
+	msg types.MarketChannelMessage
+}
+
+// Stream returns a new MessageStream receiving every parsed message of the client, in
+// addition to the callbacks. Streams outlive Disconnect and reconnects; Close closes them.
+func (ws *WebSocketClient) Stream(options *StreamOptions) *MessageStream {
+	return ws.streams.open(options)
+}
+
+// streamSet fans messages out to the streams of a client or pool
+type streamSet struct {
+	mu      sync.Mutex
+	streams []*MessageStream
+}
+
+func (set *streamSet) open(options *StreamOptions) *MessageStream {
+	stream := newMessageStream(options, set.remove)
+
+	set.mu.Lock()
+	set.streams = append(set.streams, stream)
+	set.mu.Unlock()
+
+	return stream
+}
+
+func (set *streamSet) remove(stream *MessageStream) {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	for i, s := range set.streams {
+		if s == stream {
+			set.streams = append(set.streams[:i], set.streams[i+1:]...)
+			return
+		}
+	}
+}
+
+// publish offers a message to every stream
+func (set *streamSet) publish(msg types.MarketChannelMessage) {
+	set.mu.Lock()
+	streams := append([]*MessageStream{}, set.streams...)
+	set.mu.Unlock()
+
+	for _, stream := range streams {
+		stream.push(msg)
+	}
+}
+
+// close closes every stream
+func (set *streamSet) close() {
+	set.mu.Lock()
+	streams := set.streams
+	set.streams = nil
+	set.mu.Unlock()
+
+	for _, stream := range streams {
+		stream.close()
+	}
+}
+
+func newMessageStream(options *StreamOptions, detach func(*MessageStream)) *MessageStream {
+	var opts StreamOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultStreamBufferSize
+	}
+	if opts.Overflow == "" {
+		opts.Overflow = OverflowBlock
+	}
+
+	stream := &MessageStream{
+		options: opts,
+		detach:  detach,
+		out:     make(chan types.MarketChannelMessage),
+		done:    make(chan struct{}),
+		keys:    make(map[string]*streamItem),
+	}
+	stream.cond = sync.NewCond(&stream.mu)
+	if len(opts.EventTypes) > 0 {
+		stream.eventTypes = make(map[types.EventType]bool)
+		for _, eventType := range opts.EventTypes {
+			stream.eventTypes[eventType] = true
+		}
+	}
+
+	go stream.pump()
+	return stream
+}
+
+// C returns the channel of messages. It is closed when the stream is closed.
+func (s *MessageStream) C() <-chan types.MarketChannelMessage {
+	return s.out
+}
+
+// Stats returns the delivery counters
+func (s *MessageStream) Stats() StreamStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	stats.Queued = len(s.queue)
+	return stats
+}
+
+// Dropped returns the number of messages discarded because the buffer was full
+func (s *MessageStream) Dropped() uint64 {
+	return s.Stats().Dropped
+}
+
+// Close detaches the stream from its client, discards buffered messages and closes C
+func (s *MessageStream) Close() {
+	if s.detach != nil {
+		s.detach(s)
+	}
+	s.close()
+}
+
+func (s *MessageStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.queue = nil
+	s.keys = nil
+	close(s.done)
+	s.cond.Broadcast()
+}
+
+// push buffers a message, applying the overflow policy
+func (s *MessageStream) push(msg types.MarketChannelMessage) {
+	if s.eventTypes != nil && !s.eventTypes[msg.GetEventType()] {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	var key string
+	if s.options.Overflow == OverflowCoalesce {
+		key = streamKey(msg)
+		if item, ok := s.keys[key]; ok {
+			item.msg = msg
+			s.stats.Coalesced++
+			return
+		}
+	}
+
+	for len(s.queue) >= s.options.BufferSize {
+		switch s.options.Overflow {
+		case OverflowDropNewest:
+			s.stats.Dropped++
+			return
+		case OverflowDropOldest, OverflowCoalesce:
+			s.dropOldest()
+		default:
+			s.cond.Wait()
+			if s.closed {
+				return
+			}
+		}
+	}
+
+	item := &streamItem{key: key, msg: msg}
+	s.queue = append(s.queue, item)
+	if key != "" {
+		s.keys[key] = item
+	}
+	s.cond.Broadcast()
+}
+
+// dropOldest discards the head of the queue. The caller must hold s.mu.
+func (s *MessageStream) dropOldest() {
+	item := s.queue[0]
+	s.queue = s.queue[1:]
+	if item.key != "" {
+		delete(s.keys, item.key)
+	}
+	s.stats.Dropped++
+}
+
+// pump moves buffered messages to the consumer
+func (s *MessageStream) pump() {
+	defer close(s.out)
+
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		item := s.queue[0]
+		s.queue = s.queue[1:]
+		if item.key != "" {
+			delete(s.keys, item.key)
+		}
+		msg := item.msg
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		select {
+		case s.out <- msg:
+			s.mu.Lock()
+			s.stats.Delivered++
+			s.mu.Unlock()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// streamKey identifies the asset of a message for OverflowCoalesce
+func streamKey(msg types.MarketChannelMessage) string {
+	return string(msg.GetEventType()) + "/" + strings.Join(messageAssetIDs(msg), ",")
+}
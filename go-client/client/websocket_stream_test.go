@@ -0,0 +1,105 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+func TestWebSocketStreams(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	ws := client.NewWebSocketClient(clobClient, &client.WebSocketClientOptions{
+		URL:      srv.WebSocketURL(),
+		AssetIDs: []string{"2"},
+	})
+	dropNewest := ws.Stream(&client.StreamOptions{BufferSize: 1, Overflow: client.OverflowDropNewest})
+	coalesce := ws.Stream(&client.StreamOptions{BufferSize: 4, Overflow: client.OverflowCoalesce})
+	// Streams receive messages in registration order, so once the blocking stream has a
+	// message the others have been offered it too
+	all := ws.Stream(nil)
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer ws.Disconnect()
+	waitForSubscriptions(t, srv, "2")
+
+	publish := func(bid string) {
+		t.Helper()
+		srv.SetBook("2", []types.OrderSummary{{Price: bid, Size: "1"}}, nil)
+		if err := srv.PublishBook("2"); err != nil {
+			t.Fatal(err)
+		}
+		receiveBid(t, all.C())
+	}
+
+	// The first message leaves the buffers for the (idle) consumers' channel sends
+	publish("0.1")
+	for dropNewest.Stats().Queued != 0 || coalesce.Stats().Queued != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	publish("0.2")
+	publish("0.3")
+	publish("0.4")
+
+	if stats := dropNewest.Stats(); stats.Dropped != 2 || stats.Queued != 1 {
+		t.Fatalf("unexpected drop newest stats: %+v", stats)
+	}
+	if stats := coalesce.Stats(); stats.Coalesced != 2 || stats.Queued != 1 {
+		t.Fatalf("unexpected coalesce stats: %+v", stats)
+	}
+	for _, want := range []string{"0.1", "0.2"} {
+		if got := receiveBid(t, dropNewest.C()); got != want {
+			t.Fatalf("drop newest delivered %s, want %s", got, want)
+		}
+	}
+	for _, want := range []string{"0.1", "0.4"} {
+		if got := receiveBid(t, coalesce.C()); got != want {
+			t.Fatalf("coalesce delivered %s, want %s", got, want)
+		}
+	}
+
+	// Streams stay open across Disconnect and receive the next session's messages
+	ws.Disconnect()
+	<-ws.Done()
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if got := receiveBid(t, all.C()); got != "0.4" {
+		t.Fatalf("stream delivered %s after reconnecting, want 0.4", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := ws.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case _, ok := <-all.C():
+		if ok {
+			t.Fatal("unexpected message after Close")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream not closed by Close")
+	}
+}
+
+// receiveBid receives a book message and returns its best bid
+func receiveBid(t *testing.T, ch <-chan types.MarketChannelMessage) string {
+	t.Helper()
+
+	select {
+	case msg := <-ch:
+		book, ok := types.AsBookMessage(msg)
+		if !ok || len(book.Bids) == 0 {
+			t.Fatalf("unexpected message: %+v", msg)
+		}
+		return book.Bids[0].Price
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message received")
+	}
+	return ""
+}
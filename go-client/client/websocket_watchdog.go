@@ -0,0 +1,232 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// StaleEvent reports a subscribed asset that has had no market channel message for longer
+// than WebSocketClientOptions.StaleAfter
+type StaleEvent struct {
+	AssetID string
+	// LastMessageAt is the time of the asset's last message, or of its subscription
+	LastMessageAt time.Time
+	// Snapshot is the order book fetched over REST, unless SnapshotError is set
+	Snapshot      *types.OrderBookSummary
+	SnapshotError error
+	// Resubscribed reports whether the asset was resubscribed (ResubscribeStale)
+	Resubscribed bool
+}
+
+// StaleEventHandler handles stale asset events
+type StaleEventHandler func(event *StaleEvent)
+
+// assetWatchdog tracks the last message time of each subscribed asset
+type assetWatchdog struct {
+	mu       sync.Mutex
+	lastSeen map[string]time.Time
+	// alerted holds when a silent asset was last reported, so it is reported once per
+	// StaleAfter while it stays silent
+	alerted map[string]time.Time
+}
+
+func newAssetWatchdog() *assetWatchdog {
+	return &assetWatchdog{
+		lastSeen: make(map[string]time.Time),
+		alerted:  make(map[string]time.Time),
+	}
+}
+
+// track starts the clock of assets not tracked yet
+func (w *assetWatchdog) track(ids []string, now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := w.lastSeen[id]; !ok {
+			w.lastSeen[id] = now
+		}
+	}
+}
+
+func (w *assetWatchdog) forget(ids []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, id := range ids {
+		delete(w.lastSeen, id)
+		delete(w.alerted, id)
+	}
+}
+
+// seen records a message for tracked assets
+func (w *assetWatchdog) seen(ids []string, now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := w.lastSeen[id]; ok {
+			w.lastSeen[id] = now
+			delete(w.alerted, id)
+		}
+	}
+}
+
+func (w *assetWatchdog) lastMessage(id string) (time.Time, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	t, ok := w.lastSeen[id]
+	return t, ok
+}
+
+// due returns the assets silent for longer than after that were not reported within the
+// last after, and marks them reported
+func (w *assetWatchdog) due(now time.Time, after time.Duration) map[string]time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stale := make(map[string]time.Time)
+	for id, last := range w.lastSeen {
+		if now.Sub(last) <= after {
+			continue
+		}
+		if alerted, ok := w.alerted[id]; ok && now.Sub(alerted) < after {
+			continue
+		}
+		w.alerted[id] = now
+		stale[id] = last
+	}
+	return stale
+}
+
+// IsStale reports whether a subscribed asset has been silent for longer than StaleAfter.
+// It is always false when the watchdog is disabled.
+func (ws *WebSocketClient) IsStale(assetID string) bool {
+	if ws.options.StaleAfter <= 0 {
+		return false
+	}
+	last, ok := ws.watchdog.lastMessage(assetID)
+	return ok && time.Since(last) > ws.options.StaleAfter
+}
+
+// LastMessageAt returns the time of the last market channel message for a subscribed
+// asset (its subscription time before the first message)
+func (ws *WebSocketClient) LastMessageAt(assetID string) (time.Time, bool) {
+	return ws.watchdog.lastMessage(assetID)
+}
+
+// watch checks for stale assets until the session stops
+func (ws *WebSocketClient) watch(stop chan struct{}) {
+	defer ws.watchers.Done()
+
+	interval := ws.options.StaleAfter / 4
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ws.checkStale()
+		}
+	}
+}
+
+// checkStale reports stale assets with a REST snapshot and optionally resubscribes them
+func (ws *WebSocketClient) checkStale() {
+	stale := ws.watchdog.due(time.Now(), ws.options.StaleAfter)
+	if len(stale) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(stale))
+	for id := range stale {
+		ids = append(ids, id)
+	}
+	ws.log("Stale assets:", ids)
+
+	snapshots := make(map[string]*types.OrderBookSummary)
+	var snapshotErr error
+	if ws.clobClient != nil {
+		params := make([]types.BookParams, len(ids))
+		for i, id := range ids {
+			params[i] = types.BookParams{TokenID: id}
+		}
+		books, err := ws.clobClient.GetOrderBooks(params)
+		if err != nil {
+			snapshotErr = fmt.Errorf("failed to fetch order books: %w", err)
+		}
+		for i := range books {
+			snapshots[books[i].AssetID] = &books[i]
+		}
+	} else {
+		snapshotErr = fmt.Errorf("no ClobClient to fetch order books")
+	}
+
+	// Unsubscribing and subscribing again makes the server resend the books
+	resubscribed := false
+	if ws.options.ResubscribeStale && ws.IsConnected() {
+		err := ws.writeJSON(map[string]interface{}{ws.subscriptionKey(): ids, "operation": "unsubscribe"})
+		if err == nil {
+			err = ws.writeJSON(map[string]interface{}{ws.subscriptionKey(): ids, "operation": "subscribe"})
+		}
+		if err != nil {
+			ws.handleError(fmt.Errorf("failed to resubscribe stale assets: %w", err))
+		}
+		resubscribed = err == nil
+	}
+
+	if ws.callbacks.OnStale == nil {
+		return
+	}
+	for _, id := range ids {
+		event := &StaleEvent{
+			AssetID:       id,
+			LastMessageAt: stale[id],
+			Snapshot:      snapshots[id],
+			SnapshotError: snapshotErr,
+			Resubscribed:  resubscribed,
+		}
+		if event.Snapshot == nil && event.SnapshotError == nil {
+			event.SnapshotError = fmt.Errorf("no order book returned for %s", id)
+		}
+		ws.callbacks.OnStale(event)
+	}
+}
+
+// messageAssetIDs returns the assets a market or user channel message is about
+func messageAssetIDs(msg types.MarketChannelMessage) []string {
+	switch m := msg.(type) {
+	case *types.BookMessage:
+		return []string{m.AssetID}
+	case *types.TickSizeChangeMessage:
+		return []string{m.AssetID}
+	case *types.LastTradePriceMessage:
+		return []string{m.AssetID}
+	case *types.OrderMessage:
+		return []string{m.AssetID}
+	case *types.TradeMessage:
+		return []string{m.AssetID}
+	case *types.BestBidAskMessage:
+		return []string{m.AssetID}
+	case *types.NewMarketMessage:
+		return m.AssetIDs
+	case *types.MarketResolvedMessage:
+		return m.AssetIDs
+	case *types.PriceChangeMessage:
+		ids := make([]string, 0, len(m.PriceChanges))
+		for _, change := range m.PriceChanges {
+			ids = append(ids, change.AssetID)
+		}
+		return ids
+	}
+	return nil
+}
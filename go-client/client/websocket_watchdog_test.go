@@ -0,0 +1,54 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+func TestWebSocketStaleWatchdog(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	ws := client.NewWebSocketClient(clobClient, &client.WebSocketClientOptions{
+		URL:              srv.WebSocketURL(),
+		AssetIDs:         []string{"1"},
+		StaleAfter:       50 * time.Millisecond,
+		ResubscribeStale: true,
+	})
+	stale := make(chan *client.StaleEvent, 16)
+	books := make(chan *types.BookMessage, 16)
+	ws.On(&client.WebSocketCallbacks{
+		OnStale: func(event *client.StaleEvent) { stale <- event },
+		OnBook:  func(msg *types.BookMessage) { books <- msg },
+	})
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer ws.Disconnect()
+	<-books
+
+	var event *client.StaleEvent
+	select {
+	case event = <-stale:
+	case <-time.After(2 * time.Second):
+		t.Fatal("silent asset not reported")
+	}
+	if event.AssetID != "1" || event.SnapshotError != nil || !event.Resubscribed {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+	if len(event.Snapshot.Bids) != 1 || event.Snapshot.Bids[0].Price != "0.48" {
+		t.Fatalf("unexpected snapshot: %+v", event.Snapshot)
+	}
+
+	// The resubscription makes the server resend the book, which freshens the asset
+	select {
+	case <-books:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no book after resubscribe")
+	}
+	if got := srv.Subscriptions(); len(got) != 1 || got[0] != "1" {
+		t.Fatalf("server subscriptions = %v", got)
+	}
+}
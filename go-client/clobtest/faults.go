@@ -0,0 +1,89 @@
+package clobtest
+
+import (
+	"net/http"
+	"time"
+)
+
+// Fault makes matching requests fail or stall instead of being served normally
+type Fault struct {
+	// Method and Path select the requests; empty matches any
+	Method string
+	Path   string
+
+	// Status and Body are written instead of the normal response (Status 0 serves normally
+	// after Delay, so a Fault with only Delay simulates a slow endpoint)
+	Status int
+	Body   string
+
+	// Delay is waited before responding
+	Delay time.Duration
+
+	// Times limits how many requests the fault applies to (0 = until cleared)
+	Times int
+
+	// CloseConnection drops the connection without a response
+	CloseConnection bool
+
+	hits int
+}
+
+// AddFault registers a fault; faults are checked in the order they were added
+func (s *Server) AddFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every registered fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// takeFault returns the first fault matching the request and counts the hit.
+// The caller must hold s.mu.
+func (s *Server) takeFault(method string, path string) *Fault {
+	for i, fault := range s.faults {
+		if (fault.Method != "" && fault.Method != method) || (fault.Path != "" && fault.Path != path) {
+			continue
+		}
+
+		fault.hits++
+		if fault.Times > 0 && fault.hits >= fault.Times {
+			s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+		}
+		copied := *fault
+		return &copied
+	}
+	return nil
+}
+
+// apply writes the fault and reports whether the request has been handled
+func (f *Fault) apply(w http.ResponseWriter) bool {
+	if f.Delay > 0 {
+		time.Sleep(f.Delay)
+	}
+
+	if f.CloseConnection {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return true
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	if f.Status == 0 {
+		return false
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(f.Status)
+	w.Write([]byte(f.Body))
+	return true
+}
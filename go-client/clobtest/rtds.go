@@ -0,0 +1,192 @@
+package clobtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+	"github.com/gorilla/websocket"
+)
+
+// RTDSServer is a stand-in for the real-time data service websocket. It keeps the
+// subscriptions of every connection, answers PING with PONG and delivers published
+// messages to the connections subscribed to their topic and type. Filters are recorded
+// but not applied.
+type RTDSServer struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	conns map[*rtdsConn]bool
+}
+
+// rtdsConn is an RTDS connection; writes are serialized by mu
+type rtdsConn struct {
+	conn          *websocket.Conn
+	mu            sync.Mutex
+	subscriptions []types.RTDSSubscription
+}
+
+func (c *rtdsConn) write(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (c *rtdsConn) subscribed(topic types.RTDSTopic, msgType types.RTDSMessageType) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, subscription := range c.subscriptions {
+		if subscription.Topic == topic && (subscription.Type == types.RTDSTypeAll || subscription.Type == msgType) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewRTDSServer starts an RTDS stand-in; call Close when done
+func NewRTDSServer() *RTDSServer {
+	s := &RTDSServer{conns: make(map[*rtdsConn]bool)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveWebSocket))
+	return s
+}
+
+// WebSocketURL returns the URL for RTDSClientOptions.URL
+func (s *RTDSServer) WebSocketURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// Close disconnects websocket clients and shuts the server down
+func (s *RTDSServer) Close() {
+	s.DisconnectWebSockets()
+	s.Server.Close()
+}
+
+func (s *RTDSServer) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	c := &rtdsConn{conn: conn}
+	s.mu.Lock()
+	s.conns[c] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if string(data) == "PING" {
+			if err := c.write([]byte("PONG")); err != nil {
+				return
+			}
+			continue
+		}
+
+		var action struct {
+			Action        string                   `json:"action"`
+			Subscriptions []types.RTDSSubscription `json:"subscriptions"`
+		}
+		if err := json.Unmarshal(data, &action); err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		for _, subscription := range action.Subscriptions {
+			kept := c.subscriptions[:0]
+			for _, existing := range c.subscriptions {
+				if existing != subscription {
+					kept = append(kept, existing)
+				}
+			}
+			c.subscriptions = kept
+			if action.Action == "subscribe" {
+				c.subscriptions = append(c.subscriptions, subscription)
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+// Publish sends a message with payload, encoded as JSON, to every connection subscribed
+// to topic and msgType
+func (s *RTDSServer) Publish(topic types.RTDSTopic, msgType types.RTDSMessageType, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	message, err := json.Marshal(types.RTDSMessage{
+		Topic:        topic,
+		Type:         msgType,
+		Timestamp:    time.Now().UnixMilli(),
+		ConnectionID: "clobtest",
+		Payload:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range s.connections() {
+		if c.subscribed(topic, msgType) {
+			c.write(message)
+		}
+	}
+	return nil
+}
+
+// Subscriptions returns the subscriptions of every open connection
+func (s *RTDSServer) Subscriptions() []types.RTDSSubscription {
+	var subscriptions []types.RTDSSubscription
+	for _, c := range s.connections() {
+		c.mu.Lock()
+		subscriptions = append(subscriptions, c.subscriptions...)
+		c.mu.Unlock()
+	}
+	return subscriptions
+}
+
+// DisconnectWebSockets closes every connection, e.g. to exercise reconnects
+func (s *RTDSServer) DisconnectWebSockets() {
+	for _, c := range s.connections() {
+		c.mu.Lock()
+		c.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server closing"),
+			time.Now().Add(time.Second))
+		c.mu.Unlock()
+		c.conn.Close()
+	}
+}
+
+// WebSocketConnections returns the number of open connections
+func (s *RTDSServer) WebSocketConnections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.conns)
+}
+
+func (s *RTDSServer) connections() []*rtdsConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conns := make([]*rtdsConn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
@@ -0,0 +1,980 @@
+// Package clobtest provides an in-process fake of the Polymarket CLOB REST and
+// websocket APIs for tests. It keeps markets, books, API keys, orders and trades in
+// memory, validates L1/L2 auth headers and order signatures, and can inject faults.
+//
+//	srv := clobtest.NewServer()
+//	defer srv.Close()
+//	srv.AddMarket(clobtest.Market{ConditionID: "0xc0", Tokens: []clobtest.Token{{TokenID: "1", Outcome: "Yes"}}})
+//	clobClient, err := client.NewClobClient(&client.ClientConfig{Host: srv.URL, ChainID: types.ChainPolygon, PrivateKey: key})
+package clobtest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Market is a market served by the fake server
+type Market struct {
+	ConditionID string
+	Question    string
+	Tokens      []Token
+	// TickSize defaults to 0.01
+	TickSize   types.TickSize
+	NegRisk    bool
+	FeeRateBps int
+	// MinOrderSize defaults to 5
+	MinOrderSize float64
+	Closed       bool
+}
+
+// Token is an outcome token of a Market
+type Token struct {
+	TokenID string
+	Outcome string
+	Price   float64
+}
+
+// Request is a request received by the fake server
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   string
+}
+
+// Server is a fake CLOB server backed by httptest.Server
+type Server struct {
+	*httptest.Server
+
+	// ChainID is used to verify L1 and order signatures (default Polygon)
+	ChainID types.Chain
+
+	mu         sync.Mutex
+	markets    map[string]*Market
+	marketList []string
+	tokens     map[string]*Market
+	books      map[string]*types.OrderBookSummary
+	keys       map[string]*apiKey
+	orders     map[string]*types.OpenOrder
+	orderList  []string
+	trades     []types.Trade
+	faults     []*Fault
+	requests   []Request
+	nextID     int
+
+	wsMu    sync.Mutex
+	wsConns map[*wsConn]bool
+	noPongs bool
+}
+
+type apiKey struct {
+	creds   types.ApiKeyCreds
+	address common.Address
+	nonce   uint64
+}
+
+// NewServer starts a fake CLOB server; call Close when done
+func NewServer() *Server {
+	s := &Server{
+		ChainID: types.ChainPolygon,
+		markets: make(map[string]*Market),
+		tokens:  make(map[string]*Market),
+		books:   make(map[string]*types.OrderBookSummary),
+		keys:    make(map[string]*apiKey),
+		orders:  make(map[string]*types.OpenOrder),
+		wsConns: make(map[*wsConn]bool),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// WebSocketURL returns the base URL for WebSocketClientOptions.URL
+func (s *Server) WebSocketURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// Close disconnects websocket clients and shuts the server down
+func (s *Server) Close() {
+	s.DisconnectWebSockets()
+	s.Server.Close()
+}
+
+// AddMarket adds or replaces a market and indexes its tokens
+func (s *Server) AddMarket(market Market) {
+	if market.TickSize == "" {
+		market.TickSize = types.TickSize001
+	}
+	if market.MinOrderSize == 0 {
+		market.MinOrderSize = 5
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.markets[market.ConditionID]; !ok {
+		s.marketList = append(s.marketList, market.ConditionID)
+	}
+	s.markets[market.ConditionID] = &market
+	for _, token := range market.Tokens {
+		s.tokens[token.TokenID] = &market
+	}
+}
+
+// SetBook replaces the order book of a token; the token must belong to an added market
+func (s *Server) SetBook(tokenID string, bids []types.OrderSummary, asks []types.OrderSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book := &types.OrderBookSummary{
+		AssetID:   tokenID,
+		Timestamp: strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Bids:      append([]types.OrderSummary{}, bids...),
+		Asks:      append([]types.OrderSummary{}, asks...),
+	}
+	if market, ok := s.tokens[tokenID]; ok {
+		book.Market = market.ConditionID
+		book.TickSize = string(market.TickSize)
+		book.NegRisk = market.NegRisk
+		book.MinOrderSize = strconv.FormatFloat(market.MinOrderSize, 'f', -1, 64)
+	}
+	book.Hash = types.OrderBookHash(book)
+	s.books[tokenID] = book
+}
+
+// Book returns the current book of a token
+func (s *Server) Book(tokenID string) (*types.OrderBookSummary, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book, ok := s.books[tokenID]
+	if !ok {
+		return nil, false
+	}
+	copied := *book
+	return &copied, true
+}
+
+// AddApiKey registers existing credentials for an address so clients can use them directly
+func (s *Server) AddApiKey(address string, creds types.ApiKeyCreds) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[creds.Key] = &apiKey{creds: creds, address: common.HexToAddress(address)}
+}
+
+// Orders returns every order received, in arrival order
+func (s *Server) Orders() []types.OpenOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders := make([]types.OpenOrder, 0, len(s.orderList))
+	for _, id := range s.orderList {
+		orders = append(orders, *s.orders[id])
+	}
+	return orders
+}
+
+// Trades returns every trade created with FillOrder
+func (s *Server) Trades() []types.Trade {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]types.Trade{}, s.trades...)
+}
+
+// Requests returns every request received, in arrival order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+// FillOrder matches size shares of a live order and records the trade
+func (s *Server) FillOrder(orderID string, size float64) (*types.Trade, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[orderID]
+	if !ok || order.Status != "LIVE" {
+		return nil, fmt.Errorf("no live order %s", orderID)
+	}
+
+	original, _ := strconv.ParseFloat(order.OriginalSize, 64)
+	matched, _ := strconv.ParseFloat(order.SizeMatched, 64)
+	if size <= 0 || matched+size > original+1e-9 {
+		return nil, fmt.Errorf("invalid fill size %v for order %s", size, orderID)
+	}
+
+	matched += size
+	order.SizeMatched = formatFloat(matched)
+	if matched >= original-1e-9 {
+		order.Status = "MATCHED"
+	}
+
+	s.nextID++
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	trade := types.Trade{
+		ID:           fmt.Sprintf("trade-%d", s.nextID),
+		TakerOrderID: order.ID,
+		Market:       order.Market,
+		AssetID:      order.AssetID,
+		Side:         types.Side(order.Side),
+		Size:         formatFloat(size),
+		FeeRateBps:   "0",
+		Price:        order.Price,
+		Status:       "MATCHED",
+		MatchTime:    now,
+		LastUpdate:   now,
+		Outcome:      order.Outcome,
+		Owner:        order.Owner,
+		MakerAddress: order.MakerAddress,
+		TraderSide:   "TAKER",
+	}
+	order.AssociateTrades = append(order.AssociateTrades, trade.ID)
+	s.trades = append(s.trades, trade)
+
+	s.PublishUser(order.Owner, order.Market, orderMessage(order, types.OrderEventUpdate))
+	s.PublishUser(order.Owner, order.Market, tradeMessage(&trade))
+
+	copied := trade
+	return &copied, nil
+}
+
+// SetTradeStatus moves a trade to a new settlement status (e.g. MINED, CONFIRMED, FAILED)
+// and publishes the change on the user channel
+func (s *Server) SetTradeStatus(tradeID string, status types.TradeStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.trades {
+		trade := &s.trades[i]
+		if trade.ID != tradeID {
+			continue
+		}
+		trade.Status = string(status)
+		trade.LastUpdate = strconv.FormatInt(time.Now().Unix(), 10)
+		return s.PublishUser(trade.Owner, trade.Market, tradeMessage(trade))
+	}
+	return fmt.Errorf("no trade %s", tradeID)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   string(body),
+	})
+	fault := s.takeFault(r.Method, r.URL.Path)
+	s.mu.Unlock()
+
+	if fault != nil && fault.apply(w) {
+		return
+	}
+
+	switch r.URL.Path {
+	case "/ws/market":
+		s.serveWebSocket(w, r, "market")
+		return
+	case "/ws/user":
+		s.serveWebSocket(w, r, "user")
+		return
+	}
+
+	status, result := s.route(r, string(body))
+	writeJSON(w, status, result)
+}
+
+// route dispatches a REST request and returns the status and JSON response
+func (s *Server) route(r *http.Request, body string) (int, interface{}) {
+	query := r.URL.Query()
+	path := r.URL.Path
+
+	switch {
+	case r.Method == http.MethodGet && path == "/":
+		return http.StatusOK, "OK"
+	case r.Method == http.MethodGet && path == client.Time:
+		return http.StatusOK, time.Now().Unix()
+
+	case r.Method == http.MethodGet && (path == client.GetMarkets || path == client.GetSamplingSimplifiedMarkets || path == client.GetSamplingMarkets):
+		return s.listMarkets()
+	case r.Method == http.MethodGet && strings.HasPrefix(path, client.GetMarket):
+		return s.getMarket(strings.TrimPrefix(path, client.GetMarket))
+	case r.Method == http.MethodGet && path == client.GetOrderBook:
+		return s.getBook(query.Get("token_id"))
+	case r.Method == http.MethodPost && path == client.GetOrderBooks:
+		return s.getBooks(body)
+	case r.Method == http.MethodGet && path == client.GetTickSize:
+		return s.tokenInfo(query.Get("token_id"), func(m *Market) interface{} {
+			tickSize, _ := strconv.ParseFloat(string(m.TickSize), 64)
+			return map[string]interface{}{"minimum_tick_size": tickSize}
+		})
+	case r.Method == http.MethodGet && path == client.GetNegRisk:
+		return s.tokenInfo(query.Get("token_id"), func(m *Market) interface{} {
+			return map[string]interface{}{"neg_risk": m.NegRisk}
+		})
+	case r.Method == http.MethodGet && path == client.GetFeeRate:
+		return s.tokenInfo(query.Get("token_id"), func(m *Market) interface{} {
+			return map[string]interface{}{"base_fee": m.FeeRateBps}
+		})
+	case r.Method == http.MethodGet && path == client.GetMidpoint:
+		return s.getMidpoint(query.Get("token_id"))
+	case r.Method == http.MethodGet && path == client.GetPrice:
+		return s.getPrice(query.Get("token_id"), strings.ToUpper(query.Get("side")))
+	case r.Method == http.MethodGet && path == client.GetLastTradePrice:
+		return s.getLastTradePrice(query.Get("token_id"))
+
+	case r.Method == http.MethodPost && path == client.CreateApiKey:
+		return s.createApiKey(r)
+	case r.Method == http.MethodGet && path == client.DeriveApiKey:
+		return s.deriveApiKey(r)
+	}
+
+	// Everything else requires L2 auth
+	key, status, err := s.authenticateL2(r, body)
+	if err != nil {
+		return status, errorBody(err.Error())
+	}
+
+	switch {
+	case r.Method == http.MethodGet && path == client.GetApiKeys:
+		return s.listApiKeys(key)
+	case r.Method == http.MethodDelete && path == client.DeleteApiKey:
+		return s.deleteApiKey(key)
+	case r.Method == http.MethodGet && path == client.ClosedOnly:
+		return http.StatusOK, types.BanStatus{ClosedOnly: false}
+	case r.Method == http.MethodPost && path == client.PostOrder:
+		return s.postOrder(key, body)
+	case r.Method == http.MethodDelete && path == client.CancelOrder:
+		var payload types.OrderPayload
+		if err := json.Unmarshal([]byte(body), &payload); err != nil {
+			return http.StatusBadRequest, errorBody("invalid body")
+		}
+		return s.cancelOrders(key, func(o *types.OpenOrder) bool { return o.ID == payload.OrderID })
+	case r.Method == http.MethodDelete && path == client.CancelOrders:
+		var ids []string
+		if err := json.Unmarshal([]byte(body), &ids); err != nil {
+			return http.StatusBadRequest, errorBody("invalid body")
+		}
+		return s.cancelOrders(key, func(o *types.OpenOrder) bool { return contains(ids, o.ID) })
+	case r.Method == http.MethodDelete && path == client.CancelAll:
+		return s.cancelOrders(key, func(o *types.OpenOrder) bool { return true })
+	case r.Method == http.MethodDelete && path == client.CancelMarketOrders:
+		var params types.OrderMarketCancelParams
+		if err := json.Unmarshal([]byte(body), &params); err != nil {
+			return http.StatusBadRequest, errorBody("invalid body")
+		}
+		return s.cancelOrders(key, func(o *types.OpenOrder) bool {
+			return (params.Market == nil || *params.Market == o.Market) &&
+				(params.AssetID == nil || *params.AssetID == o.AssetID)
+		})
+	case r.Method == http.MethodGet && path == client.GetOpenOrders:
+		return s.listOrders(key, r)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, client.GetOrder):
+		return s.getOrder(key, strings.TrimPrefix(path, client.GetOrder))
+	case r.Method == http.MethodGet && path == client.GetTrades:
+		return s.listTrades(key, r)
+	}
+
+	return http.StatusNotFound, errorBody("not found")
+}
+
+func (s *Server) listMarkets() (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := make([]interface{}, 0, len(s.marketList))
+	for _, id := range s.marketList {
+		data = append(data, marketJSON(s.markets[id]))
+	}
+	return http.StatusOK, types.PaginationPayload{
+		Limit:      len(data),
+		Count:      len(data),
+		NextCursor: client.EndCursor,
+		Data:       data,
+	}
+}
+
+func (s *Server) getMarket(conditionID string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	market, ok := s.markets[conditionID]
+	if !ok {
+		return http.StatusNotFound, errorBody("market not found")
+	}
+	return http.StatusOK, marketJSON(market)
+}
+
+func (s *Server) getBook(tokenID string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book, ok := s.books[tokenID]
+	if !ok {
+		return http.StatusNotFound, errorBody("No orderbook exists for the requested token id")
+	}
+	return http.StatusOK, book
+}
+
+func (s *Server) getBooks(body string) (int, interface{}) {
+	var params []types.BookParams
+	if err := json.Unmarshal([]byte(body), &params); err != nil {
+		return http.StatusBadRequest, errorBody("invalid body")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	books := make([]types.OrderBookSummary, 0, len(params))
+	for _, param := range params {
+		if book, ok := s.books[param.TokenID]; ok {
+			books = append(books, *book)
+		}
+	}
+	return http.StatusOK, books
+}
+
+func (s *Server) tokenInfo(tokenID string, info func(m *Market) interface{}) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	market, ok := s.tokens[tokenID]
+	if !ok {
+		return http.StatusNotFound, errorBody("market not found")
+	}
+	return http.StatusOK, info(market)
+}
+
+func (s *Server) getMidpoint(tokenID string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book, ok := s.books[tokenID]
+	if !ok {
+		return http.StatusNotFound, errorBody("No orderbook exists for the requested token id")
+	}
+	bid, hasBid := bestPrice(book.Bids, true)
+	ask, hasAsk := bestPrice(book.Asks, false)
+	if !hasBid || !hasAsk {
+		return http.StatusNotFound, errorBody("no match")
+	}
+	return http.StatusOK, map[string]string{"mid": formatFloat((bid + ask) / 2)}
+}
+
+func (s *Server) getPrice(tokenID string, side string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book, ok := s.books[tokenID]
+	if !ok {
+		return http.StatusNotFound, errorBody("No orderbook exists for the requested token id")
+	}
+
+	var price float64
+	var found bool
+	switch types.Side(side) {
+	case types.SideBuy:
+		price, found = bestPrice(book.Bids, true)
+	case types.SideSell:
+		price, found = bestPrice(book.Asks, false)
+	default:
+		return http.StatusBadRequest, errorBody("invalid side")
+	}
+	if !found {
+		return http.StatusNotFound, errorBody("no match")
+	}
+	return http.StatusOK, map[string]string{"price": formatFloat(price)}
+}
+
+func (s *Server) getLastTradePrice(tokenID string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.trades) - 1; i >= 0; i-- {
+		if s.trades[i].AssetID == tokenID {
+			return http.StatusOK, map[string]string{"price": s.trades[i].Price, "side": string(s.trades[i].Side)}
+		}
+	}
+	return http.StatusOK, map[string]string{"price": "0.5", "side": ""}
+}
+
+func (s *Server) createApiKey(r *http.Request) (int, interface{}) {
+	address, nonce, err := s.authenticateL1(r)
+	if err != nil {
+		return http.StatusUnauthorized, errorBody(err.Error())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findKey(address, nonce) != nil {
+		return http.StatusBadRequest, errorBody("Could not create api key")
+	}
+
+	key := &apiKey{
+		creds: types.ApiKeyCreds{
+			Key:        randomHex(16),
+			Secret:     base64.URLEncoding.EncodeToString(randomBytes(32)),
+			Passphrase: randomHex(32),
+		},
+		address: address,
+		nonce:   nonce,
+	}
+	s.keys[key.creds.Key] = key
+	return http.StatusOK, rawApiKey(key.creds)
+}
+
+func (s *Server) deriveApiKey(r *http.Request) (int, interface{}) {
+	address, nonce, err := s.authenticateL1(r)
+	if err != nil {
+		return http.StatusUnauthorized, errorBody(err.Error())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.findKey(address, nonce)
+	if key == nil {
+		return http.StatusBadRequest, errorBody("Could not derive api key!")
+	}
+	return http.StatusOK, rawApiKey(key.creds)
+}
+
+func (s *Server) listApiKeys(key *apiKey) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []string
+	for _, k := range s.keys {
+		if k.address == key.address {
+			keys = append(keys, k.creds.Key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := types.ApiKeysResponse{APIKeys: []types.ApiKeyCreds{}}
+	for _, k := range keys {
+		result.APIKeys = append(result.APIKeys, types.ApiKeyCreds{Key: k})
+	}
+	return http.StatusOK, result
+}
+
+func (s *Server) deleteApiKey(key *apiKey) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, key.creds.Key)
+	return http.StatusOK, "OK"
+}
+
+// wireOrder mirrors the order body sent by ClobClient.PostOrder
+type wireOrder struct {
+	Order struct {
+		Salt          int64               `json:"salt"`
+		Maker         string              `json:"maker"`
+		Signer        string              `json:"signer"`
+		Taker         string              `json:"taker"`
+		TokenID       string              `json:"tokenId"`
+		MakerAmount   string              `json:"makerAmount"`
+		TakerAmount   string              `json:"takerAmount"`
+		Side          types.Side          `json:"side"`
+		Expiration    string              `json:"expiration"`
+		Nonce         string              `json:"nonce"`
+		FeeRateBps    string              `json:"feeRateBps"`
+		SignatureType types.SignatureType `json:"signatureType"`
+		Signature     string              `json:"signature"`
+	} `json:"order"`
+	Owner     string          `json:"owner"`
+	OrderType types.OrderType `json:"orderType"`
+}
+
+func (s *Server) postOrder(key *apiKey, body string) (int, interface{}) {
+	var req wireOrder
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return http.StatusBadRequest, errorBody("invalid order payload")
+	}
+	if req.Owner != key.creds.Key {
+		return http.StatusBadRequest, errorBody("the order owner has to be the owner of the API KEY")
+	}
+
+	makerAmount, ok1 := new(big.Int).SetString(req.Order.MakerAmount, 10)
+	takerAmount, ok2 := new(big.Int).SetString(req.Order.TakerAmount, 10)
+	if !ok1 || !ok2 || makerAmount.Sign() <= 0 || takerAmount.Sign() <= 0 {
+		return http.StatusBadRequest, errorBody("invalid order amounts")
+	}
+
+	s.mu.Lock()
+	market, ok := s.tokens[req.Order.TokenID]
+	s.mu.Unlock()
+	if !ok {
+		return http.StatusBadRequest, errorBody("invalid token id")
+	}
+
+	signed := &types.SignedOrder{
+		Salt:          strconv.FormatInt(req.Order.Salt, 10),
+		Maker:         req.Order.Maker,
+		Signer:        req.Order.Signer,
+		Taker:         req.Order.Taker,
+		TokenID:       req.Order.TokenID,
+		MakerAmount:   makerAmount,
+		TakerAmount:   takerAmount,
+		Expiration:    req.Order.Expiration,
+		Nonce:         req.Order.Nonce,
+		FeeRateBps:    req.Order.FeeRateBps,
+		Side:          req.Order.Side,
+		SignatureType: req.Order.SignatureType,
+		Signature:     req.Order.Signature,
+	}
+
+	contracts, err := client.GetContractConfig(s.ChainID)
+	if err != nil {
+		return http.StatusInternalServerError, errorBody(err.Error())
+	}
+	hash, err := auth.BuildOrderHash(signed, int64(s.ChainID), contracts.ExchangeAddress(market.NegRisk))
+	if err != nil {
+		return http.StatusBadRequest, errorBody(err.Error())
+	}
+	recovered, err := auth.RecoverAddress(hash, signed.Signature)
+	if err != nil || recovered != common.HexToAddress(signed.Signer) {
+		return http.StatusBadRequest, errorBody("invalid signature")
+	}
+	if recovered != key.address {
+		return http.StatusBadRequest, errorBody("the order signer address has to be the address of the API KEY")
+	}
+
+	// Prices and sizes follow from the amounts: BUY gives USDC for shares, SELL the reverse
+	maker, _ := new(big.Float).SetInt(makerAmount).Float64()
+	taker, _ := new(big.Float).SetInt(takerAmount).Float64()
+	price, size := maker/taker, taker/1e6
+	if req.Order.Side == types.SideSell {
+		price, size = taker/maker, maker/1e6
+	}
+	if size < market.MinOrderSize {
+		return http.StatusBadRequest, errorBody(fmt.Sprintf("Size (%v) lower than the minimum: %v", size, market.MinOrderSize))
+	}
+
+	orderType := req.OrderType
+	if orderType == "" {
+		orderType = types.OrderTypeGTC
+	}
+
+	outcome := ""
+	for _, token := range market.Tokens {
+		if token.TokenID == req.Order.TokenID {
+			outcome = token.Outcome
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := "0x" + hex.EncodeToString(hash.Bytes())
+	if _, exists := s.orders[id]; exists {
+		return http.StatusBadRequest, errorBody("order already exists")
+	}
+
+	s.orders[id] = &types.OpenOrder{
+		ID:              id,
+		Status:          "LIVE",
+		Owner:           key.creds.Key,
+		MakerAddress:    req.Order.Maker,
+		Market:          market.ConditionID,
+		AssetID:         req.Order.TokenID,
+		Side:            string(req.Order.Side),
+		OriginalSize:    formatFloat(size),
+		SizeMatched:     "0",
+		Price:           formatFloat(price),
+		AssociateTrades: []string{},
+		Outcome:         outcome,
+		CreatedAt:       time.Now().Unix(),
+		Expiration:      req.Order.Expiration,
+		OrderType:       string(orderType),
+	}
+	s.orderList = append(s.orderList, id)
+	s.PublishUser(key.creds.Key, market.ConditionID, orderMessage(s.orders[id], types.OrderEventPlacement))
+
+	return http.StatusOK, types.OrderResponse{
+		Success:            true,
+		OrderID:            id,
+		Status:             "live",
+		TransactionsHashes: []string{},
+	}
+}
+
+func (s *Server) cancelOrders(key *apiKey, match func(o *types.OpenOrder) bool) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := types.CancelOrdersResponse{Canceled: []string{}, NotCanceled: map[string]string{}}
+	for _, id := range s.orderList {
+		order := s.orders[id]
+		if order.Owner != key.creds.Key || !match(order) {
+			continue
+		}
+		if order.Status != "LIVE" {
+			result.NotCanceled[id] = "order can't be found - already canceled or matched"
+			continue
+		}
+		order.Status = "CANCELED"
+		result.Canceled = append(result.Canceled, id)
+		s.PublishUser(order.Owner, order.Market, orderMessage(order, types.OrderEventCancellation))
+	}
+	return http.StatusOK, result
+}
+
+func (s *Server) listOrders(key *apiKey, r *http.Request) (int, interface{}) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := []types.OpenOrder{}
+	for _, id := range s.orderList {
+		order := s.orders[id]
+		if order.Owner != key.creds.Key || order.Status != "LIVE" {
+			continue
+		}
+		if !matchesQuery(query, "id", order.ID) || !matchesQuery(query, "market", order.Market) || !matchesQuery(query, "asset_id", order.AssetID) {
+			continue
+		}
+		data = append(data, *order)
+	}
+	return http.StatusOK, map[string]interface{}{"data": data, "next_cursor": client.EndCursor}
+}
+
+func (s *Server) getOrder(key *apiKey, orderID string) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[orderID]
+	if !ok || order.Owner != key.creds.Key {
+		return http.StatusNotFound, errorBody("order not found")
+	}
+	return http.StatusOK, order
+}
+
+func (s *Server) listTrades(key *apiKey, r *http.Request) (int, interface{}) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := []types.Trade{}
+	for _, trade := range s.trades {
+		if trade.Owner != key.creds.Key {
+			continue
+		}
+		if !matchesQuery(query, "id", trade.ID) || !matchesQuery(query, "market", trade.Market) || !matchesQuery(query, "asset_id", trade.AssetID) {
+			continue
+		}
+		data = append(data, trade)
+	}
+	return http.StatusOK, map[string]interface{}{"data": data, "next_cursor": client.EndCursor}
+}
+
+// authenticateL1 validates the EIP-712 ClobAuth headers
+func (s *Server) authenticateL1(r *http.Request) (common.Address, uint64, error) {
+	address := r.Header.Get("POLY_ADDRESS")
+	signature := r.Header.Get("POLY_SIGNATURE")
+	timestamp, err := strconv.ParseInt(r.Header.Get("POLY_TIMESTAMP"), 10, 64)
+	if address == "" || signature == "" || err != nil {
+		return common.Address{}, 0, fmt.Errorf("missing L1 auth headers")
+	}
+	nonce, err := strconv.ParseUint(r.Header.Get("POLY_NONCE"), 10, 64)
+	if err != nil {
+		return common.Address{}, 0, fmt.Errorf("invalid nonce")
+	}
+
+	ok, err := auth.VerifyEIP712Signature(common.HexToAddress(address).Hex(), signature, timestamp, nonce, s.ChainID)
+	if err != nil || !ok {
+		return common.Address{}, 0, fmt.Errorf("invalid L1 signature")
+	}
+	return common.HexToAddress(address), nonce, nil
+}
+
+// authenticateL2 validates the HMAC headers and returns the API key they belong to
+func (s *Server) authenticateL2(r *http.Request, body string) (*apiKey, int, error) {
+	s.mu.Lock()
+	key, ok := s.keys[r.Header.Get("POLY_API_KEY")]
+	s.mu.Unlock()
+
+	if !ok || key.creds.Passphrase != r.Header.Get("POLY_PASSPHRASE") {
+		return nil, http.StatusUnauthorized, fmt.Errorf("Unauthorized/Invalid api key")
+	}
+	if common.HexToAddress(r.Header.Get("POLY_ADDRESS")) != key.address {
+		return nil, http.StatusUnauthorized, fmt.Errorf("Unauthorized/Invalid api key")
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get("POLY_TIMESTAMP"), 10, 64)
+	if err != nil {
+		return nil, http.StatusUnauthorized, fmt.Errorf("invalid timestamp")
+	}
+
+	var signedBody *string
+	if body != "" {
+		signedBody = &body
+	}
+	if !auth.VerifyHmacSignature(key.creds.Secret, timestamp, r.Method, r.URL.Path, signedBody, r.Header.Get("POLY_SIGNATURE")) {
+		return nil, http.StatusUnauthorized, fmt.Errorf("Unauthorized/Invalid api key")
+	}
+
+	return key, http.StatusOK, nil
+}
+
+func (s *Server) findKey(address common.Address, nonce uint64) *apiKey {
+	for _, key := range s.keys {
+		if key.address == address && key.nonce == nonce {
+			return key
+		}
+	}
+	return nil
+}
+
+func marketJSON(m *Market) map[string]interface{} {
+	tokens := make([]map[string]interface{}, 0, len(m.Tokens))
+	for _, token := range m.Tokens {
+		tokens = append(tokens, map[string]interface{}{
+			"token_id": token.TokenID,
+			"outcome":  token.Outcome,
+			"price":    token.Price,
+		})
+	}
+	tickSize, _ := strconv.ParseFloat(string(m.TickSize), 64)
+	return map[string]interface{}{
+		"condition_id":       m.ConditionID,
+		"question":           m.Question,
+		"tokens":             tokens,
+		"minimum_tick_size":  tickSize,
+		"minimum_order_size": m.MinOrderSize,
+		"neg_risk":           m.NegRisk,
+		"active":             !m.Closed,
+		"closed":             m.Closed,
+		"accepting_orders":   !m.Closed,
+		"enable_order_book":  true,
+	}
+}
+
+func orderMessage(order *types.OpenOrder, eventType types.OrderEventType) types.OrderMessage {
+	return types.OrderMessage{
+		EventType:       types.EventTypeOrder,
+		ID:              order.ID,
+		Type:            eventType,
+		Owner:           order.Owner,
+		OrderOwner:      order.Owner,
+		Market:          order.Market,
+		AssetID:         order.AssetID,
+		Side:            types.Side(order.Side),
+		Price:           order.Price,
+		OriginalSize:    order.OriginalSize,
+		SizeMatched:     order.SizeMatched,
+		Outcome:         order.Outcome,
+		AssociateTrades: order.AssociateTrades,
+		Timestamp:       strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}
+}
+
+func tradeMessage(trade *types.Trade) types.TradeMessage {
+	return types.TradeMessage{
+		EventType:    types.EventTypeTrade,
+		ID:           trade.ID,
+		Type:         "TRADE",
+		Status:       types.TradeStatus(trade.Status),
+		TakerOrderID: trade.TakerOrderID,
+		Market:       trade.Market,
+		AssetID:      trade.AssetID,
+		Side:         trade.Side,
+		Size:         trade.Size,
+		Price:        trade.Price,
+		Outcome:      trade.Outcome,
+		Owner:        trade.Owner,
+		TradeOwner:   trade.Owner,
+		MakerOrders:  trade.MakerOrders,
+		MatchTime:    trade.MatchTime,
+		LastUpdate:   trade.LastUpdate,
+		Timestamp:    strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}
+}
+
+func rawApiKey(creds types.ApiKeyCreds) types.ApiKeyRaw {
+	return types.ApiKeyRaw{APIKey: creds.Key, Secret: creds.Secret, Passphrase: creds.Passphrase}
+}
+
+func bestPrice(levels []types.OrderSummary, highest bool) (float64, bool) {
+	var best float64
+	found := false
+	for _, level := range levels {
+		price, err := strconv.ParseFloat(level.Price, 64)
+		if err != nil {
+			continue
+		}
+		if !found || (highest && price > best) || (!highest && price < best) {
+			best, found = price, true
+		}
+	}
+	return best, found
+}
+
+func matchesQuery(query map[string][]string, name string, value string) bool {
+	values, ok := query[name]
+	return !ok || len(values) == 0 || values[0] == "" || values[0] == value
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	rand.Read(b)
+	return b
+}
+
+func randomHex(n int) string {
+	return hex.EncodeToString(randomBytes(n))
+}
+
+func errorBody(message string) map[string]string {
+	return map[string]string{"error": message}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(value)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,142 @@
+package clobtest_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/client"
+	"github.com/HuakunShen/polymarket-kit/go-client/clobtest"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+const testKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+func newTestClient(t *testing.T) (*clobtest.Server, *client.ClobClient) {
+	t.Helper()
+
+	srv := clobtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddMarket(clobtest.Market{
+		ConditionID: "0xc0",
+		Question:    "Will it rain?",
+		Tokens:      []clobtest.Token{{TokenID: "1", Outcome: "Yes"}, {TokenID: "2", Outcome: "No"}},
+	})
+	srv.SetBook("1",
+		[]types.OrderSummary{{Price: "0.48", Size: "100"}},
+		[]types.OrderSummary{{Price: "0.52", Size: "100"}})
+
+	clobClient, err := client.NewClobClient(&client.ClientConfig{
+		Host:       srv.URL,
+		ChainID:    types.ChainPolygon,
+		PrivateKey: testKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, clobClient
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	if _, err := clobClient.EnsureApiKey(); err != nil {
+		t.Fatalf("EnsureApiKey: %v", err)
+	}
+
+	resp, err := clobClient.CreateAndPostOrder(&types.UserOrder{
+		TokenID: "1",
+		Price:   0.5,
+		Size:    10,
+		Side:    types.SideBuy,
+	}, nil, types.OrderTypeGTC)
+	if err != nil {
+		t.Fatalf("CreateAndPostOrder: %v", err)
+	}
+	if !resp.Success || resp.OrderID == "" {
+		t.Fatalf("order rejected: %+v", resp)
+	}
+
+	open, err := clobClient.GetOpenOrders(nil, false, "")
+	if err != nil {
+		t.Fatalf("GetOpenOrders: %v", err)
+	}
+	if len(open) != 1 || open[0].Price != "0.5" || open[0].OriginalSize != "10" {
+		t.Fatalf("unexpected open orders: %+v", open)
+	}
+
+	if _, err := srv.FillOrder(resp.OrderID, 4); err != nil {
+		t.Fatalf("FillOrder: %v", err)
+	}
+	trades, err := clobClient.GetTrades(nil, false, "")
+	if err != nil {
+		t.Fatalf("GetTrades: %v", err)
+	}
+	if len(trades) != 1 || trades[0].Size != "4" {
+		t.Fatalf("unexpected trades: %+v", trades)
+	}
+
+	canceled, err := clobClient.CancelAll()
+	if err != nil {
+		t.Fatalf("CancelAll: %v", err)
+	}
+	if len(canceled.Canceled) != 1 || canceled.Canceled[0] != resp.OrderID {
+		t.Fatalf("unexpected cancel response: %+v", canceled)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+	srv.AddFault(clobtest.Fault{Path: client.GetOrderBook, Status: http.StatusServiceUnavailable, Times: 1})
+
+	if _, err := clobClient.GetOrderBook("1"); err == nil {
+		t.Fatal("expected injected fault")
+	}
+	book, err := clobClient.GetOrderBook("1")
+	if err != nil {
+		t.Fatalf("fault should have expired: %v", err)
+	}
+	if len(book.Bids) != 1 || book.Hash == "" {
+		t.Fatalf("unexpected book: %+v", book)
+	}
+}
+
+func TestMarketWebSocket(t *testing.T) {
+	srv, clobClient := newTestClient(t)
+
+	books := make(chan *types.BookMessage, 4)
+	ws := client.NewWebSocketClient(clobClient, &client.WebSocketClientOptions{
+		URL:      srv.WebSocketURL(),
+		AssetIDs: []string{"1"},
+	})
+	ws.On(&client.WebSocketCallbacks{
+		OnBook: func(msg *types.BookMessage) { books <- msg },
+	})
+	if err := ws.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer ws.Disconnect()
+
+	select {
+	case msg := <-books:
+		if msg.AssetID != "1" || len(msg.Asks) != 1 {
+			t.Fatalf("unexpected snapshot: %+v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no book snapshot received")
+	}
+
+	srv.SetBook("1", []types.OrderSummary{{Price: "0.49", Size: "50"}}, nil)
+	if err := srv.PublishBook("1"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case msg := <-books:
+		if len(msg.Bids) != 1 || msg.Bids[0].Price != "0.49" {
+			t.Fatalf("unexpected book: %+v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no published book received")
+	}
+}
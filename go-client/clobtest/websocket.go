@@ -0,0 +1,352 @@
+package clobtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+	"github.com/gorilla/websocket"
+)
+
+// upgrader accepts websocket connections from any origin
+var upgrader = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+
+// wsConn is a market or user channel connection; writes are serialized by mu
+type wsConn struct {
+	conn    *websocket.Conn
+	channel string
+	mu      sync.Mutex
+	// assets holds asset IDs on the market channel and condition IDs on the user channel
+	assets map[string]bool
+	// apiKey is the key that authenticated a user channel connection
+	apiKey string
+}
+
+func (c *wsConn) write(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (c *wsConn) subscribed(channel string, id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.channel == channel && c.assets[id]
+}
+
+// wsSubscription is a subscription or subscription change sent by a client.
+// {"assets_ids"|"markets", "type"} opens the subscription, {..., "operation"} changes it.
+type wsSubscription struct {
+	AssetsIDs []string `json:"assets_ids"`
+	Markets   []string `json:"markets"`
+	Type      string   `json:"type"`
+	Operation string   `json:"operation"`
+	Auth      *struct {
+		APIKey     string `json:"apiKey"`
+		Secret     string `json:"secret"`
+		Passphrase string `json:"passphrase"`
+	} `json:"auth"`
+}
+
+// serveWebSocket serves /ws/market and /ws/user. Market subscriptions get a book snapshot
+// per asset; user subscriptions must authenticate with a known API key. PING is answered
+// with PONG on both.
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request, channel string) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	c := &wsConn{conn: conn, channel: channel, assets: make(map[string]bool)}
+	s.wsMu.Lock()
+	s.wsConns[c] = true
+	s.wsMu.Unlock()
+
+	defer func() {
+		s.wsMu.Lock()
+		delete(s.wsConns, c)
+		s.wsMu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if string(data) == "PING" {
+			s.wsMu.Lock()
+			noPongs := s.noPongs
+			s.wsMu.Unlock()
+			if noPongs {
+				continue
+			}
+			if err := c.write([]byte("PONG")); err != nil {
+				return
+			}
+			continue
+		}
+
+		var subscription wsSubscription
+		if err := json.Unmarshal(data, &subscription); err != nil {
+			continue
+		}
+
+		ids := subscription.AssetsIDs
+		if channel == "user" {
+			ids = subscription.Markets
+			if subscription.Operation == "" && !s.authenticateUserChannel(c, &subscription) {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "invalid auth"),
+					time.Now().Add(time.Second))
+				return
+			}
+		}
+
+		c.mu.Lock()
+		for _, id := range ids {
+			if subscription.Operation == "unsubscribe" {
+				delete(c.assets, id)
+			} else {
+				c.assets[id] = true
+			}
+		}
+		c.mu.Unlock()
+
+		if channel == "user" || subscription.Operation == "unsubscribe" {
+			continue
+		}
+
+		var books []types.BookMessage
+		for _, assetID := range ids {
+			if book, ok := s.Book(assetID); ok {
+				books = append(books, bookMessage(book))
+			}
+		}
+		if len(books) > 0 {
+			payload, _ := json.Marshal(books)
+			if err := c.write(payload); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// authenticateUserChannel checks the credentials of a user channel subscription
+func (s *Server) authenticateUserChannel(c *wsConn, subscription *wsSubscription) bool {
+	if subscription.Auth == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	key, ok := s.keys[subscription.Auth.APIKey]
+	s.mu.Unlock()
+
+	if !ok || key.creds.Secret != subscription.Auth.Secret || key.creds.Passphrase != subscription.Auth.Passphrase {
+		return false
+	}
+
+	c.mu.Lock()
+	c.apiKey = key.creds.Key
+	c.mu.Unlock()
+	return true
+}
+
+// PublishMarket sends msg to every market channel connection subscribed to assetID. msg is
+// encoded as JSON, so any market channel message type (or a raw map) can be published.
+func (s *Server) PublishMarket(assetID string, msg interface{}) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range s.webSocketConns() {
+		if c.subscribed("market", assetID) {
+			c.write(payload)
+		}
+	}
+	return nil
+}
+
+// PublishUser sends msg to the user channel connections of apiKey that subscribed to the
+// market (condition ID), or to no market in particular. Order placement, cancellation and
+// FillOrder publish their events automatically.
+func (s *Server) PublishUser(apiKey string, market string, msg interface{}) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range s.webSocketConns() {
+		c.mu.Lock()
+		matches := c.channel == "user" && c.apiKey == apiKey && (len(c.assets) == 0 || c.assets[market])
+		c.mu.Unlock()
+
+		if matches {
+			c.write(payload)
+		}
+	}
+	return nil
+}
+
+// PublishBook sends the current book of a token, as set by SetBook, to its subscribers
+func (s *Server) PublishBook(tokenID string) error {
+	book, ok := s.Book(tokenID)
+	if !ok {
+		return nil
+	}
+	return s.PublishMarket(tokenID, bookMessage(book))
+}
+
+// UpdateLevel sets the size of one price level of a token's book (size "0" removes it) and
+// publishes the matching price_change with the new book hash and best bid/ask
+func (s *Server) UpdateLevel(tokenID string, side types.Side, price string, size string) error {
+	s.mu.Lock()
+	book, ok := s.books[tokenID]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("no book for token %s", tokenID)
+	}
+
+	if side == types.SideBuy {
+		book.Bids = setLevel(book.Bids, price, size, false)
+	} else {
+		book.Asks = setLevel(book.Asks, price, size, true)
+	}
+	book.Timestamp = strconv.FormatInt(time.Now().UnixMilli(), 10)
+	book.Hash = types.OrderBookHash(book)
+
+	change := types.PriceChange{
+		AssetID: tokenID,
+		Price:   price,
+		Size:    size,
+		Side:    side,
+		Hash:    book.Hash,
+		BestBid: "0",
+		BestAsk: "1",
+	}
+	if bid, ok := bestPrice(book.Bids, true); ok {
+		change.BestBid = strconv.FormatFloat(bid, 'f', -1, 64)
+	}
+	if ask, ok := bestPrice(book.Asks, false); ok {
+		change.BestAsk = strconv.FormatFloat(ask, 'f', -1, 64)
+	}
+	msg := types.PriceChangeMessage{
+		EventType:    types.EventTypePriceChange,
+		Market:       book.Market,
+		PriceChanges: []types.PriceChange{change},
+		Timestamp:    book.Timestamp,
+	}
+	s.mu.Unlock()
+
+	return s.PublishMarket(tokenID, msg)
+}
+
+// DisconnectWebSockets closes every websocket connection, e.g. to exercise reconnects
+func (s *Server) DisconnectWebSockets() {
+	for _, c := range s.webSocketConns() {
+		c.mu.Lock()
+		c.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server closing"),
+			time.Now().Add(time.Second))
+		c.mu.Unlock()
+		c.conn.Close()
+	}
+}
+
+// SetPongs turns PONG replies on (the default) or off, so clients see a connection that
+// is open but dead
+func (s *Server) SetPongs(enabled bool) {
+	s.wsMu.Lock()
+	defer s.wsMu.Unlock()
+
+	s.noPongs = !enabled
+}
+
+// Subscriptions returns the IDs subscribed on any websocket connection, sorted: asset IDs
+// on the market channel and condition IDs on the user channel
+func (s *Server) Subscriptions() []string {
+	seen := make(map[string]bool)
+	for _, c := range s.webSocketConns() {
+		c.mu.Lock()
+		for assetID := range c.assets {
+			seen[assetID] = true
+		}
+		c.mu.Unlock()
+	}
+
+	assetIDs := make([]string, 0, len(seen))
+	for assetID := range seen {
+		assetIDs = append(assetIDs, assetID)
+	}
+	sort.Strings(assetIDs)
+	return assetIDs
+}
+
+// WebSocketConnections returns the number of open websocket connections
+func (s *Server) WebSocketConnections() int {
+	s.wsMu.Lock()
+	defer s.wsMu.Unlock()
+
+	return len(s.wsConns)
+}
+
+func (s *Server) webSocketConns() []*wsConn {
+	s.wsMu.Lock()
+	defer s.wsMu.Unlock()
+
+	conns := make([]*wsConn, 0, len(s.wsConns))
+	for c := range s.wsConns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+// setLevel replaces, inserts or removes a level, keeping bids ascending and asks descending
+// as the CLOB lists them
+func setLevel(levels []types.OrderSummary, price string, size string, descending bool) []types.OrderSummary {
+	value, _ := strconv.ParseFloat(price, 64)
+	result := make([]types.OrderSummary, 0, len(levels)+1)
+	for _, level := range levels {
+		if existing, _ := strconv.ParseFloat(level.Price, 64); existing != value {
+			result = append(result, level)
+		}
+	}
+	if sizeValue, _ := strconv.ParseFloat(size, 64); sizeValue > 0 {
+		result = append(result, types.OrderSummary{Price: price, Size: size})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, _ := strconv.ParseFloat(result[i].Price, 64)
+		b, _ := strconv.ParseFloat(result[j].Price, 64)
+		if descending {
+			return a > b
+		}
+		return a < b
+	})
+	return result
+}
+
+func bookMessage(book *types.OrderBookSummary) types.BookMessage {
+	timestamp := book.Timestamp
+	if timestamp == "" {
+		timestamp = strconv.FormatInt(time.Now().UnixMilli(), 10)
+	}
+	return types.BookMessage{
+		EventType: types.EventTypeBook,
+		AssetID:   book.AssetID,
+		Market:    book.Market,
+		Timestamp: timestamp,
+		Hash:      book.Hash,
+		Bids:      book.Bids,
+		Asks:      book.Asks,
+	}
+}
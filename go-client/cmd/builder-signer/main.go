@@ -0,0 +1,44 @@
+// Command builder-signer serves the auth.RemoteBuilderSigner protocol so builder
+// credentials live in one service instead of every trading process.
+//
+// Usage:
+//
+//	POLY_BUILDER_API_KEY=... POLY_BUILDER_SECRET=... POLY_BUILDER_PASSPHRASE=... \
+//	POLY_BUILDER_SIGNER_TOKEN=... \
+//	  builder-signer -listen 127.0.0.1:8551
+//
+// Clients then use auth.NewRemoteBuilderSigner with Endpoint "http://127.0.0.1:8551/sign".
+// POLY_BUILDER_SIGNER_TOKEN is required for TCP listeners.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+)
+
+func main() {
+	listen := flag.String("listen", "unix:///tmp/polymarket-builder-signer.sock", "unix:///path/to.sock or host:port")
+	flag.Parse()
+
+	config := &auth.BuilderConfig{
+		APIKey:     os.Getenv("POLY_BUILDER_API_KEY"),
+		Secret:     os.Getenv("POLY_BUILDER_SECRET"),
+		Passphrase: os.Getenv("POLY_BUILDER_PASSPHRASE"),
+	}
+	if !config.IsValid() {
+		log.Fatal("POLY_BUILDER_API_KEY, POLY_BUILDER_SECRET and POLY_BUILDER_PASSPHRASE are required")
+	}
+
+	token := os.Getenv("POLY_BUILDER_SIGNER_TOKEN")
+	listener, err := auth.ListenSigner(*listen, token)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", *listen, err)
+	}
+
+	log.Printf("Serving builder signer for key %s on %s", config.APIKey, *listen)
+	log.Fatal(http.Serve(listener, auth.NewBuilderSignerHandler(config, token)))
+}
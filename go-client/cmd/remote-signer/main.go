@@ -0,0 +1,49 @@
+// Command remote-signer serves the auth.RemoteSigner protocol for a keystore
+// file so trading processes never load the private key themselves.
+//
+// Usage:
+//
+//	POLY_SIGNER_PASSPHRASE=... remote-signer -keystore ./key.json -listen unix:///run/polymarket/signer.sock
+//	POLY_SIGNER_PASSPHRASE=... POLY_SIGNER_TOKEN=... remote-signer -keystore ./key.json -listen 127.0.0.1:8550
+//
+// POLY_SIGNER_TOKEN is required for TCP listeners. Hash signing (POST /sign/hash) is off
+// unless -allow-hash-signing is given.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+)
+
+func main() {
+	keystorePath := flag.String("keystore", "", "path to an encrypted V3 keystore file")
+	listen := flag.String("listen", "unix:///tmp/polymarket-signer.sock", "unix:///path/to.sock or host:port")
+	allowHashSigning := flag.Bool("allow-hash-signing", false, "serve /sign/hash, which signs arbitrary digests")
+	flag.Parse()
+
+	if *keystorePath == "" {
+		log.Fatal("-keystore is required")
+	}
+
+	token := os.Getenv("POLY_SIGNER_TOKEN")
+	listener, err := auth.ListenSigner(*listen, token)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", *listen, err)
+	}
+
+	signer, err := auth.NewKeystoreSigner(*keystorePath, os.Getenv("POLY_SIGNER_PASSPHRASE"))
+	if err != nil {
+		log.Fatalf("Failed to unlock keystore: %v", err)
+	}
+
+	log.Printf("Serving signer %s on %s", signer.Address().Hex(), *listen)
+	handler := auth.NewSignerHandler(signer, &auth.SignerHandlerConfig{
+		AuthToken:        token,
+		AllowHashSigning: *allowHashSigning,
+	})
+	log.Fatal(http.Serve(listener, handler))
+}
@@ -6,9 +6,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"reflect"
-	"strings"
 	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/querystring"
 )
 
 const (
@@ -62,6 +62,11 @@ func NewDataSDK(config *DataSDKConfig) *DataSDK {
 		}
 	}
 
+	// A custom transport (e.g. an httprecord.Recorder) takes precedence over the proxy
+	if config != nil && config.Transport != nil {
+		httpClient.Transport = config.Transport
+	}
+
 	client := &DataSDK{
 		baseURL:     DataAPIBase,
 		proxyConfig: proxyConfig,
@@ -83,69 +88,11 @@ func (d *DataSDK) buildURL(endpoint string, query interface{}) (string, error) {
 		return "", fmt.Errorf("failed to parse URL: %w", err)
 	}
 
-	if query != nil {
-		values := url.Values{}
-		v := reflect.ValueOf(query)
-
-		// Dereference pointer if necessary
-		if v.Kind() == reflect.Ptr {
-			if v.IsNil() {
-				return u.String(), nil
-			}
-			v = v.Elem()
-		}
-
-		t := v.Type()
-
-		for i := 0; i < t.NumField(); i++ {
-			field := t.Field(i)
-			fieldValue := v.Field(i)
-
-			// Skip nil pointer fields
-			if fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() {
-				continue
-			}
-
-			// Get the JSON tag for the field name
-			jsonTag := field.Tag.Get("json")
-			if jsonTag == "" {
-				continue
-			}
-
-			// Handle omitempty
-			if strings.Contains(jsonTag, "omitempty") && fieldValue.IsZero() {
-				continue
-			}
-
-			// Extract field name from JSON tag
-			fieldName := strings.Split(jsonTag, ",")[0]
-			if fieldName == "" {
-				continue
-			}
-
-			// Convert value to string and add to query params
-			var strValue string
-			if fieldValue.Kind() == reflect.Ptr {
-				strValue = fmt.Sprintf("%v", fieldValue.Elem().Interface())
-			} else {
-				strValue = fmt.Sprintf("%v", fieldValue.Interface())
-			}
-
-			// Handle slice fields for array parameters
-			if fieldValue.Kind() == reflect.Slice {
-				slice := fieldValue.Interface()
-				if sliceValue, ok := slice.([]string); ok {
-					for _, item := range sliceValue {
-						values.Add(fieldName, item)
-					}
-				}
-			} else {
-				values.Add(fieldName, strValue)
-			}
-		}
-
-		u.RawQuery = values.Encode()
+	values, err := querystring.Values(query)
+	if err != nil {
+		return "", err
 	}
+	u.RawQuery = values.Encode()
 
 	return u.String(), nil
 }
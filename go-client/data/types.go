@@ -1,5 +1,7 @@
 package data
 
+import "net/http"
+
 // ProxyConfig represents HTTP/HTTPS proxy configuration
 type ProxyConfig struct {
 	Host     string  `json:"host"`
@@ -12,6 +14,9 @@ type ProxyConfig struct {
 // DataSDKConfig represents configuration for the Data SDK
 type DataSDKConfig struct {
 	Proxy *ProxyConfig `json:"proxy,omitempty"` // HTTP/HTTPS proxy configuration
+
+	// Transport replaces the HTTP transport, e.g. to record or replay traffic
+	Transport http.RoundTripper `json:"-"`
 }
 
 // Position represents a user's position from the Data API
@@ -175,8 +180,8 @@ type DataHealthResponse struct {
 // PositionsQuery represents query parameters for positions
 type PositionsQuery struct {
 	User          *string   `json:"user,omitempty"`
-	Market        *[]string `json:"market,omitempty"`
-	EventID       *[]string `json:"eventId,omitempty"`
+	Market        *[]string `json:"market,omitempty" query:",comma"`
+	EventID       *[]string `json:"eventId,omitempty" query:",comma"`
 	SizeThreshold *float64  `json:"sizeThreshold,omitempty"`
 	Redeemable    *bool     `json:"redeemable,omitempty"`
 	Mergeable     *bool     `json:"mergeable,omitempty"`
@@ -190,8 +195,8 @@ type PositionsQuery struct {
 // ClosedPositionsQuery represents query parameters for closed positions
 type ClosedPositionsQuery struct {
 	User          *string   `json:"user,omitempty"`
-	Market        *[]string `json:"market,omitempty"`
-	EventID       *[]string `json:"eventId,omitempty"`
+	Market        *[]string `json:"market,omitempty" query:",comma"`
+	EventID       *[]string `json:"eventId,omitempty" query:",comma"`
 	Title         *string   `json:"title,omitempty"`
 	Limit         *int      `json:"limit,omitempty"`
 	Offset        *int      `json:"offset,omitempty"`
@@ -206,8 +211,8 @@ type TradesQuery struct {
 	TakerOnly    *bool     `json:"takerOnly,omitempty"`
 	FilterType   *string   `json:"filterType,omitempty"`
 	FilterAmount *float64  `json:"filterAmount,omitempty"`
-	Market       *[]string `json:"market,omitempty"`
-	EventID      *[]string `json:"eventId,omitempty"`
+	Market       *[]string `json:"market,omitempty" query:",comma"`
+	EventID      *[]string `json:"eventId,omitempty" query:",comma"`
 	User         *string   `json:"user,omitempty"`
 	Side         *string   `json:"side,omitempty"` // "BUY" or "SELL"
 }
@@ -217,8 +222,8 @@ type UserActivityQuery struct {
 	User          *string   `json:"user,omitempty"`
 	Limit         *int      `json:"limit,omitempty"`
 	Offset        *int      `json:"offset,omitempty"`
-	Market        *[]string `json:"market,omitempty"`
-	EventID       *[]string `json:"eventId,omitempty"`
+	Market        *[]string `json:"market,omitempty" query:",comma"`
+	EventID       *[]string `json:"eventId,omitempty" query:",comma"`
 	Type          *string   `json:"type,omitempty"` // "BUY", "SELL", "CANCEL", "FUND", "REDEEM"
 	Start         *string   `json:"start,omitempty"`
 	End           *string   `json:"end,omitempty"`
@@ -229,15 +234,15 @@ type UserActivityQuery struct {
 
 // TopHoldersQuery represents query parameters for top holders
 type TopHoldersQuery struct {
-	Limit      *int     `json:"limit,omitempty"`      // 0-500, default 100
-	Market     []string `json:"market"`               // Required, comma-separated condition IDs
-	MinBalance *int     `json:"minBalance,omitempty"` // 0-999999, default 1
+	Limit      *int     `json:"limit,omitempty"`       // 0-500, default 100
+	Market     []string `json:"market" query:",comma"` // Required, comma-separated condition IDs
+	MinBalance *int     `json:"minBalance,omitempty"`  // 0-999999, default 1
 }
 
 // TotalValueQuery represents query parameters for total value
 type TotalValueQuery struct {
-	User   *string   `json:"user,omitempty"`   // Required
-	Market *[]string `json:"market,omitempty"` // Optional
+	User   *string   `json:"user,omitempty"`                  // Required
+	Market *[]string `json:"market,omitempty" query:",comma"` // Optional
 }
 
 // TotalMarketsTradedQuery represents query parameters for total markets traded
@@ -247,7 +252,7 @@ type TotalMarketsTradedQuery struct {
 
 // OpenInterestQuery represents query parameters for open interest
 type OpenInterestQuery struct {
-	Market []string `json:"market"` // Required, array of Hash64 strings
+	Market []string `json:"market" query:",comma"` // Required, array of Hash64 strings
 }
 
 // LiveVolumeQuery represents query parameters for live volume
@@ -81,7 +81,7 @@ func main() {
 	nonce := uint64(0)
 	chainID := int64(137) // Polygon
 
-	eip712Sig, err := auth.BuildClobEip712Signature(wallet.GetPrivateKey(), chainID, timestamp, nonce)
+	eip712Sig, err := auth.BuildClobEip712Signature(wallet.Signer(), chainID, timestamp, nonce)
 	if err != nil {
 		log.Fatalf("Failed to build EIP712 signature: %v", err)
 	}
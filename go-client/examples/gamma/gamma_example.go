@@ -87,7 +87,7 @@ func main() {
 		if len(markets) > 0 {
 			market := markets[0]
 			fmt.Printf("   First market: %s\n", market.Question)
-			fmt.Printf("   Outcomes: %v\n", market.Outcomes)
+			fmt.Printf("   Outcomes: %v\n", market.OutcomeNames)
 			fmt.Printf("   Active: %v\n", market.Active)
 		}
 	}
@@ -6,9 +6,10 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"reflect"
 	"strings"
 	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/querystring"
 )
 
 const (
@@ -19,6 +20,9 @@ const (
 // GammaSDKConfig represents configuration for the Gamma SDK
 type GammaSDKConfig struct {
 	Proxy *ProxyConfig `json:"proxy,omitempty"` // HTTP/HTTPS proxy configuration
+
+	// Transport replaces the HTTP transport, e.g. to record or replay traffic
+	Transport http.RoundTripper `json:"-"`
 }
 
 // GammaSDK represents the Polymarket Gamma API SDK
@@ -67,6 +71,11 @@ func NewGammaSDK(config *GammaSDKConfig) *GammaSDK {
 		}
 	}
 
+	// A custom transport (e.g. an httprecord.Recorder) takes precedence over the proxy
+	if config != nil && config.Transport != nil {
+		httpClient.Transport = config.Transport
+	}
+
 	client := &GammaSDK{
 		baseURL:     GammaAPIBase,
 		proxyConfig: proxyConfig,
@@ -88,59 +97,11 @@ func (g *GammaSDK) buildURL(endpoint string, query interface{}) (string, error)
 		return "", fmt.Errorf("failed to parse URL: %w", err)
 	}
 
-	if query != nil {
-		values := url.Values{}
-		v := reflect.ValueOf(query)
-
-		// Dereference pointer if necessary
-		if v.Kind() == reflect.Ptr {
-			if v.IsNil() {
-				return u.String(), nil
-			}
-			v = v.Elem()
-		}
-
-		t := v.Type()
-
-		for i := 0; i < t.NumField(); i++ {
-			field := t.Field(i)
-			fieldValue := v.Field(i)
-
-			// Skip nil pointer fields
-			if fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() {
-				continue
-			}
-
-			// Get the JSON tag for the field name
-			jsonTag := field.Tag.Get("json")
-			if jsonTag == "" {
-				continue
-			}
-
-			// Handle omitempty
-			if strings.Contains(jsonTag, "omitempty") && fieldValue.IsZero() {
-				continue
-			}
-
-			// Extract field name from JSON tag
-			fieldName := strings.Split(jsonTag, ",")[0]
-			if fieldName == "" {
-				continue
-			}
-
-			// Convert value to string and add to query params
-			var strValue string
-			if fieldValue.Kind() == reflect.Ptr {
-				strValue = fmt.Sprintf("%v", fieldValue.Elem().Interface())
-			} else {
-				strValue = fmt.Sprintf("%v", fieldValue.Interface())
-			}
-
-			values.Add(fieldName, strValue)
-		}
-
-		u.RawQuery = values.Encode()
+	values, err := querystring.Values(query)
+	if err != nil {
+		return "", err
 	}
+	u.RawQuery = values.Encode()
 
 	return u.String(), nil
 }
@@ -348,19 +309,12 @@ func (g *GammaSDK) unmarshalEventsResponse(resp *APIResponse, operation string)
 		return nil, err
 	}
 
-	// Parse as array of maps first to transform each item
-	var rawItems []map[string]interface{}
-	if err := json.Unmarshal(data, &rawItems); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal %s data: %w", operation, err)
-	}
-
-	// Transform each event
-	events := make([]Event, len(rawItems))
-	for i, item := range rawItems {
-		events[i] = g.transformEventData(item)
+	var result []Event
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s response: %w", operation, err)
 	}
 
-	return events, nil
+	return result, nil
 }
 
 // unmarshalMarketsResponse extracts and unmarshals markets response
@@ -370,19 +324,12 @@ func (g *GammaSDK) unmarshalMarketsResponse(resp *APIResponse, operation string)
 		return nil, err
 	}
 
-	// Parse as array of maps first to transform each item
-	var rawItems []map[string]interface{}
-	if err := json.Unmarshal(data, &rawItems); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal %s data: %w", operation, err)
-	}
-
-	// Transform each market
-	markets := make([]Market, len(rawItems))
-	for i, item := range rawItems {
-		markets[i] = g.transformMarketData(item)
+	var result []Market
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s response: %w", operation, err)
 	}
 
-	return markets, nil
+	return result, nil
 }
 
 // unmarshalSearchResponse extracts and unmarshals search response
@@ -400,101 +347,6 @@ func (g *GammaSDK) unmarshalSearchResponse(resp *APIResponse, operation string)
 	return &result, nil
 }
 
-// parseJSONArray parses a JSON array from either string or already parsed array
-func (g *GammaSDK) parseJSONArray(value interface{}) []string {
-	if value == nil {
-		return []string{}
-	}
-
-	switch v := value.(type) {
-	case []interface{}:
-		// Already an array of interfaces
-		result := make([]string, len(v))
-		for i, item := range v {
-			result[i] = fmt.Sprintf("%v", item)
-		}
-		return result
-	case []string:
-		// Already a string array
-		return v
-	case string:
-		// JSON string that needs to be parsed
-		var result []string
-		if err := json.Unmarshal([]byte(v), &result); err != nil {
-			// If parsing fails, return empty array
-			return []string{}
-		}
-		return result
-	default:
-		// Any other type, convert to string
-		return []string{fmt.Sprintf("%v", v)}
-	}
-}
-
-// transformMarketData transforms market data to parse JSON string fields
-func (g *GammaSDK) transformMarketData(item map[string]interface{}) Market {
-	market := Market{}
-
-	// Use json.Unmarshal for proper type conversion
-	itemBytes, _ := json.Marshal(item)
-	json.Unmarshal(itemBytes, &market)
-
-	// Parse JSON array fields that might be strings
-	if outcomes, ok := item["outcomes"]; ok {
-		market.Outcomes = g.parseJSONArray(outcomes)
-	}
-
-	if outcomePrices, ok := item["outcomePrices"]; ok {
-		market.OutcomePrices = g.parseJSONArray(outcomePrices)
-	}
-
-	if clobTokenIds, ok := item["clobTokenIds"]; ok {
-		market.ClobTokenIDs = g.parseJSONArray(clobTokenIds)
-	}
-
-	return market
-}
-
-// transformEventData transforms event data to parse JSON string fields in nested markets
-func (g *GammaSDK) transformEventData(item map[string]interface{}) Event {
-	event := Event{}
-
-	// Use json.Unmarshal for proper type conversion
-	itemBytes, _ := json.Marshal(item)
-	json.Unmarshal(itemBytes, &event)
-
-	// Transform nested markets
-	if marketsData, ok := item["markets"]; ok {
-		if markets, ok := marketsData.([]interface{}); ok {
-			event.Markets = make([]EventMarket, len(markets))
-			for i, marketItem := range markets {
-				if marketMap, ok := marketItem.(map[string]interface{}); ok {
-					market := EventMarket{}
-					marketBytes, _ := json.Marshal(marketMap)
-					json.Unmarshal(marketBytes, &market)
-
-					// Parse JSON array fields
-					if outcomes, ok := marketMap["outcomes"]; ok {
-						market.Outcomes = g.parseJSONArray(outcomes)
-					}
-
-					if outcomePrices, ok := marketMap["outcomePrices"]; ok {
-						market.OutcomePrices = g.parseJSONArray(outcomePrices)
-					}
-
-					if clobTokenIds, ok := marketMap["clobTokenIds"]; ok {
-						market.ClobTokenIDs = g.parseJSONArray(clobTokenIds)
-					}
-
-					event.Markets[i] = market
-				}
-			}
-		}
-	}
-
-	return event
-}
-
 // Health check
 // GetHealth performs a health check on the Gamma API
 func (g *GammaSDK) GetHealth() (map[string]interface{}, error) {
@@ -650,26 +502,12 @@ func (g *GammaSDK) GetEventsPaginated(query PaginatedEventQuery) (*PaginatedEven
 		return nil, err
 	}
 
-	// Parse the paginated response
-	var rawResponse struct {
-		Data       []map[string]interface{} `json:"data"`
-		Pagination Pagination               `json:"pagination"`
-	}
-
-	if err := json.Unmarshal(data, &rawResponse); err != nil {
+	var result PaginatedEventsResponse
+	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal paginated events response: %w", err)
 	}
 
-	// Transform each event
-	events := make([]Event, len(rawResponse.Data))
-	for i, item := range rawResponse.Data {
-		events[i] = g.transformEventData(item)
-	}
-
-	return &PaginatedEventsResponse{
-		Data:       events,
-		Pagination: rawResponse.Pagination,
-	}, nil
+	return &result, nil
 }
 
 // GetEventById gets a specific event by ID
@@ -692,13 +530,11 @@ func (g *GammaSDK) GetEventById(id int, query *EventByIdQuery) (*Event, error) {
 		return nil, err
 	}
 
-	// Parse and transform the event
-	var rawEvent map[string]interface{}
-	if err := json.Unmarshal(data, &rawEvent); err != nil {
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal event data: %w", err)
 	}
 
-	event := g.transformEventData(rawEvent)
 	return &event, nil
 }
 
@@ -732,13 +568,11 @@ func (g *GammaSDK) GetEventBySlug(slug string, query *EventByIdQuery) (*Event, e
 		return nil, err
 	}
 
-	// Parse and transform the event
-	var rawEvent map[string]interface{}
-	if err := json.Unmarshal(data, &rawEvent); err != nil {
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal event data: %w", err)
 	}
 
-	event := g.transformEventData(rawEvent)
 	return &event, nil
 }
 
@@ -777,13 +611,11 @@ func (g *GammaSDK) GetMarketById(id int, query *MarketByIdQuery) (*Market, error
 		return nil, err
 	}
 
-	// Parse and transform the market
-	var rawMarket map[string]interface{}
-	if err := json.Unmarshal(data, &rawMarket); err != nil {
+	var market Market
+	if err := json.Unmarshal(data, &market); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal market data: %w", err)
 	}
 
-	market := g.transformMarketData(rawMarket)
 	return &market, nil
 }
 
@@ -817,13 +649,11 @@ func (g *GammaSDK) GetMarketBySlug(slug string, query *MarketByIdQuery) (*Market
 		return nil, err
 	}
 
-	// Parse and transform the market
-	var rawMarket map[string]interface{}
-	if err := json.Unmarshal(data, &rawMarket); err != nil {
+	var market Market
+	if err := json.Unmarshal(data, &market); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal market data: %w", err)
 	}
 
-	market := g.transformMarketData(rawMarket)
 	return &market, nil
 }
 
@@ -0,0 +1,295 @@
+package gamma
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPageSize   = 100
+	defaultRetries    = 2
+	defaultRetryDelay = 500 * time.Millisecond
+)
+
+// IteratorOptions configures the iterators of the list endpoints
+type IteratorOptions struct {
+	// Items per request (default the query's Limit, or 100)
+	PageSize int
+
+	// Pages requested ahead of the one being read, concurrently (0 = one request at a time)
+	Prefetch int
+
+	// Stop after this many items (0 = no cap)
+	MaxItems int
+
+	// Additional attempts for a failed page request (default 2, negative disables)
+	Retries int
+
+	// Delay before the first retry, doubled after every failed attempt (default 500ms)
+	RetryDelay time.Duration
+}
+
+// pageFetcher requests one page of a list endpoint
+type pageFetcher[T any] func(offset int, limit int) ([]T, error)
+
+// pageResult is a fetched page; done is closed when it is ready
+type pageResult[T any] struct {
+	offset int
+	items  []T
+	err    error
+	done   chan struct{}
+}
+
+// Iterator lazily walks a list endpoint page by page, following limit/offset until a
+// short page. It is not safe for concurrent use.
+//
+//	it := sdk.IterateMarkets(&gamma.UpdatedMarketQuery{Closed: &closed}, nil)
+//	defer it.Stop()
+//	for it.Next() {
+//		market := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	fetch   pageFetcher[T]
+	options IteratorOptions
+
+	nextOffset int
+	requested  int
+	pending    []*pageResult[T]
+	exhausted  bool
+
+	page    []T
+	index   int
+	value   T
+	yielded int
+	err     error
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newIterator[T any](fetch pageFetcher[T], offset *int, limit *int, options *IteratorOptions) *Iterator[T] {
+	it := &Iterator[T]{fetch: fetch, stop: make(chan struct{})}
+	if options != nil {
+		it.options = *options
+	}
+	if it.options.PageSize <= 0 {
+		it.options.PageSize = defaultPageSize
+		if limit != nil && *limit > 0 {
+			it.options.PageSize = *limit
+		}
+	}
+	if it.options.Prefetch < 0 {
+		it.options.Prefetch = 0
+	}
+	if it.options.Retries == 0 {
+		it.options.Retries = defaultRetries
+	}
+	if it.options.RetryDelay <= 0 {
+		it.options.RetryDelay = defaultRetryDelay
+	}
+	if offset != nil {
+		it.nextOffset = *offset
+	}
+	return it
+}
+
+// Next advances to the next item. It returns false when the endpoint has no more items,
+// MaxItems is reached, Stop was called or a page failed after its retries; check Err.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil || it.stopped() {
+		return false
+	}
+	if it.options.MaxItems > 0 && it.yielded >= it.options.MaxItems {
+		it.Stop()
+		return false
+	}
+
+	for it.index >= len(it.page) {
+		if !it.loadPage() {
+			return false
+		}
+	}
+
+	it.value = it.page[it.index]
+	it.index++
+	it.yielded++
+	return true
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that ended the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Stop ends the iteration early and abandons prefetched pages and retries. Next returns
+// false afterwards.
+func (it *Iterator[T]) Stop() {
+	it.stopOnce.Do(func() { close(it.stop) })
+}
+
+// All collects the remaining items
+func (it *Iterator[T]) All() ([]T, error) {
+	defer it.Stop()
+
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+func (it *Iterator[T]) stopped() bool {
+	select {
+	case <-it.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// loadPage makes the next page current, keeping Prefetch requests ahead of it
+func (it *Iterator[T]) loadPage() bool {
+	if it.exhausted && len(it.pending) == 0 {
+		return false
+	}
+	for !it.exhausted && len(it.pending) <= it.options.Prefetch {
+		it.request()
+	}
+
+	result := it.pending[0]
+	it.pending = it.pending[1:]
+	select {
+	case <-result.done:
+	case <-it.stop:
+		return false
+	}
+
+	if result.err != nil {
+		it.err = result.err
+		it.Stop()
+		return false
+	}
+	if len(result.items) < it.options.PageSize {
+		// A short page is the last one; prefetched pages after it are empty
+		it.exhausted = true
+		it.pending = nil
+	}
+
+	it.page = result.items
+	it.index = 0
+	if it.options.MaxItems > 0 && len(it.page) > it.options.MaxItems-it.yielded {
+		it.page = it.page[:it.options.MaxItems-it.yielded]
+	}
+	return true
+}
+
+// request starts fetching the page at nextOffset
+func (it *Iterator[T]) request() {
+	result := &pageResult[T]{offset: it.nextOffset, done: make(chan struct{})}
+	it.pending = append(it.pending, result)
+	it.nextOffset += it.options.PageSize
+
+	it.requested += it.options.PageSize
+	if it.options.MaxItems > 0 && it.requested >= it.options.MaxItems {
+		// Pages past MaxItems are never needed
+		it.exhausted = true
+	}
+
+	limit := it.options.PageSize
+	go func() {
+		defer close(result.done)
+		result.items, result.err = it.fetchWithRetry(result.offset, limit)
+	}()
+}
+
+// fetchWithRetry requests a page, retrying with exponential backoff
+func (it *Iterator[T]) fetchWithRetry(offset int, limit int) ([]T, error) {
+	delay := it.options.RetryDelay
+	for attempt := 0; ; attempt++ {
+		items, err := it.fetch(offset, limit)
+		if err == nil {
+			return items, nil
+		}
+		if attempt >= it.options.Retries {
+			return nil, fmt.Errorf("page at offset %d: %w", offset, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-it.stop:
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// IterateEvents walks GetEvents. The query's Limit and Offset set the page size and the
+// starting offset; the query is not modified.
+func (g *GammaSDK) IterateEvents(query *UpdatedEventQuery, options *IteratorOptions) *Iterator[Event] {
+	if query == nil {
+		query = &UpdatedEventQuery{}
+	}
+	base := *query
+	return newIterator(func(offset int, limit int) ([]Event, error) {
+		q := base
+		q.Offset, q.Limit = &offset, &limit
+		return g.GetEvents(&q)
+	}, base.Offset, base.Limit, options)
+}
+
+// IterateMarkets walks GetMarkets. The query's Limit and Offset set the page size and the
+// starting offset; the query is not modified.
+func (g *GammaSDK) IterateMarkets(query *UpdatedMarketQuery, options *IteratorOptions) *Iterator[Market] {
+	if query == nil {
+		query = &UpdatedMarketQuery{}
+	}
+	base := *query
+	return newIterator(func(offset int, limit int) ([]Market, error) {
+		q := base
+		q.Offset, q.Limit = &offset, &limit
+		return g.GetMarkets(&q)
+	}, base.Offset, base.Limit, options)
+}
+
+// IterateComments walks GetComments. The query's Limit and Offset set the page size and
+// the starting offset; the query is not modified.
+func (g *GammaSDK) IterateComments(query *CommentQuery, options *IteratorOptions) *Iterator[Comment] {
+	if query == nil {
+		query = &CommentQuery{}
+	}
+	base := *query
+	return newIterator(func(offset int, limit int) ([]Comment, error) {
+		q := base
+		q.Offset, q.Limit = &offset, &limit
+		return g.GetComments(&q)
+	}, base.Offset, base.Limit, options)
+}
+
+// IterateSeries walks GetSeries. The query's Limit and Offset set the page size and the
+// starting offset.
+func (g *GammaSDK) IterateSeries(query SeriesQuery, options *IteratorOptions) *Iterator[Series] {
+	return newIterator(func(offset int, limit int) ([]Series, error) {
+		q := query
+		q.Offset, q.Limit = &offset, &limit
+		return g.GetSeries(q)
+	}, query.Offset, query.Limit, options)
+}
+
+// IterateTags walks GetTags. The query's Limit and Offset set the page size and the
+// starting offset.
+func (g *GammaSDK) IterateTags(query TagQuery, options *IteratorOptions) *Iterator[UpdatedTag] {
+	return newIterator(func(offset int, limit int) ([]UpdatedTag, error) {
+		q := query
+		q.Offset, q.Limit = &offset, &limit
+		return g.GetTags(q)
+	}, query.Offset, query.Limit, options)
+}
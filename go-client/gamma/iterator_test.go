@@ -0,0 +1,129 @@
+package gamma_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/gamma"
+)
+
+// tagServer answers GET /tags from a list of total tags, failing the first request at
+// failOffset (if not negative)
+type tagServer struct {
+	total      int
+	failOffset int
+
+	mu       sync.Mutex
+	requests []string
+	failed   bool
+}
+
+func (s *tagServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req.URL.RawQuery)
+	fail := offset == s.failOffset && !s.failed
+	if fail {
+		s.failed = true
+	}
+	s.mu.Unlock()
+
+	if fail {
+		return response(http.StatusServiceUnavailable, `{"error":"busy"}`), nil
+	}
+
+	tags := []gamma.UpdatedTag{}
+	for i := offset; i < offset+limit && i < s.total; i++ {
+		tags = append(tags, gamma.UpdatedTag{ID: strconv.Itoa(i), Slug: fmt.Sprintf("tag-%d", i)})
+	}
+	body, _ := json.Marshal(tags)
+	return response(http.StatusOK, string(body)), nil
+}
+
+func (s *tagServer) requestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.requests)
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestIterateTags(t *testing.T) {
+	srv := &tagServer{total: 250, failOffset: 100}
+	sdk := gamma.NewGammaSDK(&gamma.GammaSDKConfig{Transport: srv})
+
+	it := sdk.IterateTags(gamma.TagQuery{}, &gamma.IteratorOptions{
+		Prefetch:   2,
+		RetryDelay: time.Millisecond,
+	})
+	tags, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 250 {
+		t.Fatalf("got %d tags, want 250", len(tags))
+	}
+	for i, tag := range tags {
+		if tag.ID != strconv.Itoa(i) {
+			t.Fatalf("tag %d has ID %s; pages out of order", i, tag.ID)
+		}
+	}
+	if !srv.failed {
+		t.Error("the failing page was never requested")
+	}
+}
+
+func TestIterateTagsMaxItemsAndStop(t *testing.T) {
+	srv := &tagServer{total: 1000, failOffset: -1}
+	sdk := gamma.NewGammaSDK(&gamma.GammaSDKConfig{Transport: srv})
+
+	offset := 10
+	tags, err := sdk.IterateTags(gamma.TagQuery{Offset: &offset}, &gamma.IteratorOptions{
+		PageSize: 50,
+		MaxItems: 120,
+	}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 120 || tags[0].ID != "10" || tags[119].ID != "129" {
+		t.Fatalf("got %d tags from %s", len(tags), tags[0].ID)
+	}
+	if n := srv.requestCount(); n != 3 {
+		t.Errorf("%d requests for 120 items in pages of 50, want 3", n)
+	}
+
+	it := sdk.IterateTags(gamma.TagQuery{}, nil)
+	for i := 0; i < 5 && it.Next(); i++ {
+	}
+	it.Stop()
+	if it.Next() {
+		t.Error("Next returned true after Stop")
+	}
+}
+
+func TestIterateTagsError(t *testing.T) {
+	srv := &tagServer{total: 250, failOffset: 0}
+	sdk := gamma.NewGammaSDK(&gamma.GammaSDKConfig{Transport: srv})
+
+	_, err := sdk.IterateTags(gamma.TagQuery{}, &gamma.IteratorOptions{Retries: -1}).All()
+	if err == nil || !strings.Contains(err.Error(), "offset 0") {
+		t.Fatalf("err = %v, want the failed page", err)
+	}
+}
@@ -0,0 +1,215 @@
+package gamma
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the layouts Gamma uses for dates, tried in order
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05.999999Z07",
+	"2006-01-02 15:04:05.999999Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	time.DateOnly,
+	"January 2, 2006",
+}
+
+var (
+	float64Type = reflect.TypeOf(float64(0))
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// UnmarshalJSON decodes a market as the API sends it: numbers as strings, arrays as
+// JSON-encoded strings and dates in several layouts
+func (m *Market) UnmarshalJSON(data []byte) error {
+	type plain Market
+	data, err := normalizeJSON(data, reflect.TypeOf(plain{}))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, (*plain)(m))
+}
+
+// UnmarshalJSON decodes an event and its markets as the API sends them
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type plain Event
+	data, err := normalizeJSON(data, reflect.TypeOf(plain{}))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+// UnmarshalJSON decodes a series as the API sends it; competitive, for one, is a string
+func (s *Series) UnmarshalJSON(data []byte) error {
+	type plain Series
+	data, err := normalizeJSON(data, reflect.TypeOf(plain{}))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// UnmarshalJSON decodes an event's tag as the API sends it
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type plain Tag
+	data, err := normalizeJSON(data, reflect.TypeOf(plain{}))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// UnmarshalJSON decodes a reward program as the API sends it
+func (r *MarketReward) UnmarshalJSON(data []byte) error {
+	type plain MarketReward
+	data, err := normalizeJSON(data, reflect.TypeOf(plain{}))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// Outcomes pairs each outcome name with its CLOB token ID and price, in the API's order.
+// Missing token IDs or prices are left empty.
+func (m *Market) Outcomes() []MarketOutcome {
+	outcomes := make([]MarketOutcome, len(m.OutcomeNames))
+	for i, name := range m.OutcomeNames {
+		outcomes[i].Name = name
+		if i < len(m.ClobTokenIDs) {
+			outcomes[i].TokenID = m.ClobTokenIDs[i]
+		}
+		if i < len(m.OutcomePrices) {
+			outcomes[i].Price = m.OutcomePrices[i]
+		}
+	}
+	return outcomes
+}
+
+// Outcome returns the outcome with the given name, case-insensitively
+func (m *Market) Outcome(name string) (MarketOutcome, bool) {
+	for _, outcome := range m.Outcomes() {
+		if strings.EqualFold(outcome.Name, name) {
+			return outcome, true
+		}
+	}
+	return MarketOutcome{}, false
+}
+
+// normalizeJSON rewrites a JSON object so it decodes into t: for float64 fields numeric
+// strings become numbers, for time.Time fields dates become RFC 3339, and for slice
+// fields JSON-encoded strings become arrays. Values that cannot be converted are dropped.
+func normalizeJSON(data []byte, t reflect.Type) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return data, nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		raw, ok := fields[name]
+		if !ok || name == "" {
+			continue
+		}
+
+		value, ok := normalizeValue(raw, field.Type)
+		if !ok {
+			delete(fields, name)
+			continue
+		}
+		fields[name] = value
+	}
+	return json.Marshal(fields)
+}
+
+// normalizeValue converts raw for a field of type t; false means the value is dropped
+func normalizeValue(raw json.RawMessage, t reflect.Type) (json.RawMessage, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '"' {
+		if t.Kind() == reflect.Slice && t.Elem() == float64Type && len(raw) > 0 && raw[0] == '[' {
+			return normalizeFloats(raw)
+		}
+		return raw, true
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, false
+	}
+
+	switch {
+	case t == float64Type:
+		if s == "" {
+			return nil, false
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, false
+		}
+		return json.RawMessage(strconv.FormatFloat(f, 'g', -1, 64)), true
+
+	case t == timeType:
+		for _, layout := range dateLayouts {
+			if parsed, err := time.Parse(layout, s); err == nil {
+				value, _ := json.Marshal(parsed)
+				return value, true
+			}
+		}
+		return nil, false
+
+	case t.Kind() == reflect.Slice:
+		if s == "" {
+			return nil, false
+		}
+		inner := json.RawMessage(s)
+		if !json.Valid(inner) {
+			return nil, false
+		}
+		if t.Elem() == float64Type {
+			return normalizeFloats(inner)
+		}
+		return inner, true
+	}
+	return raw, true
+}
+
+// normalizeFloats converts an array of numbers or numeric strings to numbers
+func normalizeFloats(raw json.RawMessage) (json.RawMessage, bool) {
+	var items []interface{}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, false
+	}
+
+	values := make([]float64, len(items))
+	for i, item := range items {
+		switch v := item.(type) {
+		case float64:
+			values[i] = v
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, false
+			}
+			values[i] = f
+		default:
+			return nil, false
+		}
+	}
+	data, _ := json.Marshal(values)
+	return data, true
+}
@@ -0,0 +1,158 @@
+package gamma_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/gamma"
+)
+
+const sampleEvent = `{
+	"id": "16167",
+	"slug": "fed-decision-in-march",
+	"title": "Fed decision in March?",
+	"startDate": "2024-01-10T17:00:00Z",
+	"endDate": "2024-03-20",
+	"liquidity": "1250.5",
+	"volume": 98765.25,
+	"updatedAt": "2024-03-01 12:30:00+00",
+	"series": [{
+		"id": "10345",
+		"ticker": "fed-interest-rates",
+		"slug": "fed-interest-rates",
+		"title": "Fed Interest Rates",
+		"seriesType": "single",
+		"recurrence": "monthly",
+		"active": true,
+		"closed": false,
+		"archived": false,
+		"volume": 1250000.5,
+		"liquidity": 45000,
+		"startDate": "2023-12-01T00:00:00Z",
+		"createdAt": "2023-11-30T18:02:11.51Z",
+		"updatedAt": "2024-03-01T12:30:00Z",
+		"competitive": "0.87",
+		"volume24hr": 2500,
+		"commentCount": 12
+	}],
+	"tags": [{"id": "100196", "label": "Fed", "slug": "fed", "forceShow": false, "createdAt": "2024-01-03T18:55:03.27Z"}],
+	"markets": [{
+		"id": "253591",
+		"question": "Fed cuts rates in March?",
+		"conditionId": "0xabc",
+		"slug": "fed-cuts-rates-in-march",
+		"outcomes": "[\"Yes\", \"No\"]",
+		"outcomePrices": "[\"0.125\", \"0.875\"]",
+		"clobTokenIds": "[\"111\", \"222\"]",
+		"umaResolutionStatuses": "[]",
+		"negRisk": true,
+		"enableOrderBook": true,
+		"orderPriceMinTickSize": 0.001,
+		"orderMinSize": 5,
+		"bestBid": 0.12,
+		"bestAsk": 0.13,
+		"liquidity": "1250.5",
+		"volume": "98765.25",
+		"volume24hr": 1500,
+		"umaBond": "500",
+		"spread": "",
+		"startDate": "2024-01-10T17:00:00.123Z",
+		"endDateIso": "2024-03-20",
+		"closedTime": "2024-03-20 18:05:41+00",
+		"createdAt": "not a date",
+		"clobRewards": [{"id": "1", "rewardsDailyRate": "25", "startDate": "2024-01-10"}],
+		"events": [{"id": "16167", "slug": "fed-decision-in-march", "series": [{"id": "10345", "competitive": "0"}]}]
+	}]
+}`
+
+func TestEventDecodesAPIFormats(t *testing.T) {
+	var event gamma.Event
+	if err := json.Unmarshal([]byte(sampleEvent), &event); err != nil {
+		t.Fatal(err)
+	}
+
+	if event.Liquidity == nil || *event.Liquidity != 1250.5 || event.Volume == nil || *event.Volume != 98765.25 {
+		t.Errorf("event liquidity/volume = %v/%v", event.Liquidity, event.Volume)
+	}
+	if !event.EndDate.Equal(time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("event end date = %v", event.EndDate)
+	}
+	if !event.UpdatedAt.Equal(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)) {
+		t.Errorf("event updatedAt = %v", event.UpdatedAt)
+	}
+	if len(event.Series) != 1 || event.Series[0].Competitive == nil || *event.Series[0].Competitive != 0.87 ||
+		event.Series[0].Volume == nil || *event.Series[0].Volume != 1250000.5 {
+		t.Errorf("series = %+v", event.Series)
+	}
+	if len(event.Tags) != 1 || event.Tags[0].Slug != "fed" {
+		t.Errorf("tags = %+v", event.Tags)
+	}
+	if len(event.Markets) != 1 {
+		t.Fatalf("got %d markets", len(event.Markets))
+	}
+
+	market := event.Markets[0]
+	if market.Liquidity != 1250.5 || market.Volume != 98765.25 || market.Volume24hr != 1500 || market.UMABond != 500 {
+		t.Errorf("market numbers = %v %v %v %v", market.Liquidity, market.Volume, market.Volume24hr, market.UMABond)
+	}
+	if market.Spread != 0 || !market.CreatedAt.IsZero() {
+		t.Errorf("unparsable values should be zero: spread %v, createdAt %v", market.Spread, market.CreatedAt)
+	}
+	if !market.NegRisk || !market.EnableOrderBook || market.OrderPriceMinTickSize != 0.001 || market.OrderMinSize != 5 {
+		t.Errorf("order book fields = %+v", market)
+	}
+	if market.StartDate.Nanosecond() != 123000000 || market.ClosedTime.Hour() != 18 || market.EndDateIso.Day() != 20 {
+		t.Errorf("dates = %v %v %v", market.StartDate, market.ClosedTime, market.EndDateIso)
+	}
+	if len(market.ClobRewards) != 1 || market.ClobRewards[0].RewardsDailyRate != 25 || market.ClobRewards[0].StartDate.Year() != 2024 {
+		t.Errorf("rewards = %+v", market.ClobRewards)
+	}
+	if len(market.Events) != 1 || len(market.Events[0].Series) != 1 || market.Events[0].Series[0].Competitive == nil {
+		t.Errorf("market events = %+v", market.Events)
+	}
+	if market.UMAResolutionStatuses == nil || len(market.UMAResolutionStatuses) != 0 {
+		t.Errorf("umaResolutionStatuses = %#v", market.UMAResolutionStatuses)
+	}
+
+	want := []gamma.MarketOutcome{
+		{Name: "Yes", TokenID: "111", Price: 0.125},
+		{Name: "No", TokenID: "222", Price: 0.875},
+	}
+	outcomes := market.Outcomes()
+	if len(outcomes) != len(want) {
+		t.Fatalf("outcomes = %+v", outcomes)
+	}
+	for i := range want {
+		if outcomes[i] != want[i] {
+			t.Errorf("outcome %d = %+v, want %+v", i, outcomes[i], want[i])
+		}
+	}
+	if no, ok := market.Outcome("no"); !ok || no.TokenID != "222" {
+		t.Errorf("Outcome(no) = %+v, %v", no, ok)
+	}
+}
+
+func TestMarketDecodesPlainArrays(t *testing.T) {
+	var market gamma.Market
+	data := `{"outcomes": ["Up", "Down"], "outcomePrices": [0.4, "0.6"], "clobTokenIds": ["1"]}`
+	if err := json.Unmarshal([]byte(data), &market); err != nil {
+		t.Fatal(err)
+	}
+
+	outcomes := market.Outcomes()
+	if len(outcomes) != 2 || outcomes[1].Price != 0.6 || outcomes[1].TokenID != "" || outcomes[0].TokenID != "1" {
+		t.Errorf("outcomes = %+v", outcomes)
+	}
+}
+
+func TestSeriesDecodesAPIFormats(t *testing.T) {
+	var series []gamma.Series
+	data := `[{"id": "2", "slug": "nba", "competitive": "0.5", "volume": "1000"}, {"id": "3", "competitive": ""}]`
+	if err := json.Unmarshal([]byte(data), &series); err != nil {
+		t.Fatal(err)
+	}
+	if len(series) != 2 || *series[0].Competitive != 0.5 || *series[0].Volume != 1000 || series[1].Competitive != nil {
+		t.Errorf("series = %+v", series)
+	}
+}
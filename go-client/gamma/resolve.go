@@ -0,0 +1,303 @@
+package gamma
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ErrNotFound is returned by Resolve when no event or market matches the input
+var ErrNotFound = errors.New("gamma: no matching event or market")
+
+// InputKind is the kind of reference ParseInput recognized
+type InputKind string
+
+const (
+	// InputEventURL is polymarket.com/event/<event-slug>
+	InputEventURL InputKind = "event_url"
+	// InputMarketURL is polymarket.com/event/<event-slug>/<market-slug> or /market/<market-slug>
+	InputMarketURL InputKind = "market_url"
+	// InputSportsURL is polymarket.com/sports/<league>/.../<event-slug>
+	InputSportsURL InputKind = "sports_url"
+	// InputSlug is a bare event or market slug
+	InputSlug InputKind = "slug"
+	// InputTokenID is a CLOB token ID
+	InputTokenID InputKind = "token_id"
+	// InputConditionID is a market's condition ID
+	InputConditionID InputKind = "condition_id"
+)
+
+var (
+	conditionIDPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	tokenIDPattern     = regexp.MustCompile(`^[0-9]{20,}$`)
+	slugPattern        = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
+
+// Reference is a parsed Resolve input. Only the fields of its Kind are set; Slug is set
+// for bare slugs, which may name an event or a market.
+type Reference struct {
+	Kind        InputKind
+	EventSlug   string
+	MarketSlug  string
+	Slug        string
+	TokenID     string
+	ConditionID string
+}
+
+// ResolvedMarket is a market with its outcomes paired to their CLOB tokens
+type ResolvedMarket struct {
+	Market      *Market
+	ConditionID string
+	Outcomes    []MarketOutcome
+
+	// Yes and No are the two outcomes of a binary market, set when Binary is true. For
+	// markets whose outcomes are not named Yes/No (e.g. two teams), Yes is the first
+	// outcome and No the second.
+	Binary bool
+	Yes    MarketOutcome
+	No     MarketOutcome
+}
+
+// Resolution is the result of Resolve
+type Resolution struct {
+	Reference Reference
+
+	// Event is the parent event; nil if the market has none
+	Event *Event
+
+	// Markets are the markets the input names: every market of the event for event URLs
+	// and event slugs, the one market otherwise
+	Markets []ResolvedMarket
+}
+
+// ConditionIDs returns the condition IDs of the resolved markets
+func (r *Resolution) ConditionIDs() []string {
+	ids := make([]string, 0, len(r.Markets))
+	for _, market := range r.Markets {
+		ids = append(ids, market.ConditionID)
+	}
+	return ids
+}
+
+// TokenIDs returns the CLOB token IDs of every outcome of the resolved markets
+func (r *Resolution) TokenIDs() []string {
+	var ids []string
+	for _, market := range r.Markets {
+		for _, outcome := range market.Outcomes {
+			ids = append(ids, outcome.TokenID)
+		}
+	}
+	return ids
+}
+
+// ParseInput recognizes a polymarket.com URL (with or without scheme, locale prefix or
+// query string), a bare slug, a CLOB token ID or a condition ID, without any request
+func ParseInput(input string) (Reference, error) {
+	input = strings.TrimSpace(input)
+	switch {
+	case input == "":
+		return Reference{}, errors.New("gamma: empty input")
+	case conditionIDPattern.MatchString(input):
+		return Reference{Kind: InputConditionID, ConditionID: strings.ToLower(input)}, nil
+	case tokenIDPattern.MatchString(input):
+		return Reference{Kind: InputTokenID, TokenID: input}, nil
+	case slugPattern.MatchString(input):
+		return Reference{Kind: InputSlug, Slug: input}, nil
+	}
+	return parseURL(input)
+}
+
+func parseURL(input string) (Reference, error) {
+	raw := input
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Reference{}, fmt.Errorf("gamma: invalid input %q: %w", input, err)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host != "polymarket.com" && !strings.HasSuffix(host, ".polymarket.com") {
+		return Reference{}, fmt.Errorf("gamma: %q is not a polymarket.com URL", input)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	for i, segment := range segments {
+		rest := segments[i+1:]
+		switch segment {
+		case "event":
+			switch len(rest) {
+			case 1:
+				return Reference{Kind: InputEventURL, EventSlug: rest[0]}, nil
+			case 2:
+				return Reference{Kind: InputMarketURL, EventSlug: rest[0], MarketSlug: rest[1]}, nil
+			}
+		case "market":
+			if len(rest) == 1 {
+				return Reference{Kind: InputMarketURL, MarketSlug: rest[0]}, nil
+			}
+		case "sports", "esports":
+			// The game's event slug is the last segment, after the league and any
+			// /games/week/<n>/ path
+			if len(rest) >= 2 && strings.Contains(rest[len(rest)-1], "-") {
+				return Reference{Kind: InputSportsURL, EventSlug: rest[len(rest)-1]}, nil
+			}
+		default:
+			// Skip a locale prefix such as /zh/ or /pt-BR/
+			if i == 0 && len(segment) <= 5 {
+				continue
+			}
+		}
+		break
+	}
+	return Reference{}, fmt.Errorf("gamma: unrecognized polymarket.com URL %q", input)
+}
+
+// Resolve turns a polymarket.com URL, event or market slug, CLOB token ID or condition ID
+// into its event and markets, with each market's outcomes paired to their CLOB tokens.
+// It returns ErrNotFound if nothing matches or the match has no markets, so Markets is
+// never empty.
+//
+//	res, err := sdk.Resolve("https://polymarket.com/event/fed-decision-in-october/fed-cuts-25bps")
+//	yesToken := res.Markets[0].Yes.TokenID
+func (g *GammaSDK) Resolve(input string) (*Resolution, error) {
+	res, err := g.resolve(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Markets) == 0 {
+		return nil, fmt.Errorf("%w: %s has no markets", ErrNotFound, input)
+	}
+	return res, nil
+}
+
+func (g *GammaSDK) resolve(input string) (*Resolution, error) {
+	ref, err := ParseInput(input)
+	if err != nil {
+		return nil, err
+	}
+	res := &Resolution{Reference: ref}
+
+	switch ref.Kind {
+	case InputEventURL, InputSportsURL:
+		event, err := g.GetEventBySlug(ref.EventSlug, nil)
+		if err != nil {
+			return nil, err
+		}
+		if event == nil {
+			// Some links use a market slug in place of the event's
+			return g.resolveMarketSlug(res, ref.EventSlug)
+		}
+		res.setEvent(event)
+		return res, nil
+
+	case InputMarketURL:
+		if ref.EventSlug != "" {
+			event, err := g.GetEventBySlug(ref.EventSlug, nil)
+			if err != nil {
+				return nil, err
+			}
+			if event != nil {
+				for i := range event.Markets {
+					if event.Markets[i].Slug == ref.MarketSlug {
+						res.Event = event
+						res.addMarket(&event.Markets[i])
+						return res, nil
+					}
+				}
+			}
+		}
+		return g.resolveMarketSlug(res, ref.MarketSlug)
+
+	case InputSlug:
+		event, err := g.GetEventBySlug(ref.Slug, nil)
+		if err != nil {
+			return nil, err
+		}
+		if event != nil {
+			res.setEvent(event)
+			return res, nil
+		}
+		return g.resolveMarketSlug(res, ref.Slug)
+
+	case InputTokenID:
+		return g.resolveMarketQuery(res, &UpdatedMarketQuery{ClobTokenIDs: []string{ref.TokenID}})
+
+	case InputConditionID:
+		return g.resolveMarketQuery(res, &UpdatedMarketQuery{ConditionIDs: []string{ref.ConditionID}})
+	}
+	return nil, fmt.Errorf("gamma: unsupported input kind %s", ref.Kind)
+}
+
+func (g *GammaSDK) resolveMarketSlug(res *Resolution, slug string) (*Resolution, error) {
+	market, err := g.GetMarketBySlug(slug, nil)
+	if err != nil {
+		return nil, err
+	}
+	if market == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, slug)
+	}
+	return g.resolveMarket(res, market)
+}
+
+func (g *GammaSDK) resolveMarketQuery(res *Resolution, query *UpdatedMarketQuery) (*Resolution, error) {
+	markets, err := g.GetMarkets(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("%w: %s%s", ErrNotFound, res.Reference.TokenID, res.Reference.ConditionID)
+	}
+	return g.resolveMarket(res, &markets[0])
+}
+
+// resolveMarket sets the market and fetches its parent event with all of its markets,
+// falling back to the event embedded in the market
+func (g *GammaSDK) resolveMarket(res *Resolution, market *Market) (*Resolution, error) {
+	if len(market.Events) > 0 {
+		parent := market.Events[0]
+		res.Event = &parent
+		if parent.Slug != "" {
+			event, err := g.GetEventBySlug(parent.Slug, nil)
+			if err != nil {
+				return nil, err
+			}
+			if event != nil {
+				res.Event = event
+			}
+		}
+	}
+	res.addMarket(market)
+	return res, nil
+}
+
+func (r *Resolution) setEvent(event *Event) {
+	r.Event = event
+	for i := range event.Markets {
+		r.addMarket(&event.Markets[i])
+	}
+}
+
+func (r *Resolution) addMarket(market *Market) {
+	resolved := ResolvedMarket{
+		Market:      market,
+		ConditionID: market.ConditionID,
+		Outcomes:    market.Outcomes(),
+	}
+	if len(resolved.Outcomes) == 2 {
+		resolved.Binary = true
+		resolved.Yes, resolved.No = resolved.Outcomes[0], resolved.Outcomes[1]
+		if strings.EqualFold(resolved.Yes.Name, "no") && strings.EqualFold(resolved.No.Name, "yes") {
+			resolved.Yes, resolved.No = resolved.No, resolved.Yes
+		}
+	}
+	r.Markets = append(r.Markets, resolved)
+}
@@ -0,0 +1,144 @@
+package gamma_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/gamma"
+)
+
+const (
+	resolveEvent = `{"id": "1", "slug": "fed-decision", "title": "Fed decision",
+		"series": [{"id": "7", "slug": "fed-interest-rates", "recurrence": "monthly", "competitive": "0.87"}],
+		"markets": [
+		{"id": "10", "slug": "fed-cuts", "conditionId": "0xaa", "outcomes": "[\"Yes\", \"No\"]", "clobTokenIds": "[\"101\", \"102\"]"},
+		{"id": "11", "slug": "fed-holds", "conditionId": "0xbb", "outcomes": "[\"No\", \"Yes\"]", "clobTokenIds": "[\"111\", \"112\"]"}
+	]}`
+	resolveMarket = `{"id": "11", "slug": "fed-holds", "conditionId": "0xbb", "outcomes": "[\"No\", \"Yes\"]",
+		"clobTokenIds": "[\"111\", \"112\"]", "events": [{"id": "1", "slug": "fed-decision",
+		"series": [{"id": "7", "slug": "fed-interest-rates", "competitive": "0.87"}]}]}`
+	resolveGame = `{"id": "2", "slug": "nba-lal-gsw-2025-10-21",
+		"series": [{"id": "10345", "ticker": "nba", "slug": "nba", "title": "NBA", "seriesType": "single",
+			"recurrence": "daily", "active": true, "volume": 1250000.5, "competitive": "0", "commentCount": 12}],
+		"tags": [{"id": "745", "label": "NBA", "slug": "nba", "forceShow": false}],
+		"markets": [
+		{"id": "20", "slug": "nba-lal-gsw-2025-10-21", "conditionId": "0xcc", "outcomes": "[\"Lakers\", \"Warriors\"]", "clobTokenIds": "[\"201\", \"202\"]"}
+	]}`
+	resolveEmptyEvent = `{"id": "3", "slug": "empty-event", "title": "No markets yet", "markets": []}`
+)
+
+// resolveServer answers the slug and market lookups used by Resolve
+type resolveServer struct{}
+
+func (resolveServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	switch {
+	case req.URL.Path == "/events/slug/fed-decision":
+		return response(http.StatusOK, resolveEvent), nil
+	case req.URL.Path == "/events/slug/nba-lal-gsw-2025-10-21":
+		return response(http.StatusOK, resolveGame), nil
+	case req.URL.Path == "/events/slug/empty-event":
+		return response(http.StatusOK, resolveEmptyEvent), nil
+	case req.URL.Path == "/markets/slug/fed-holds":
+		return response(http.StatusOK, resolveMarket), nil
+	case req.URL.Path == "/markets" && (query.Get("clob_token_ids") == "11200000000000000000000" ||
+		query.Get("condition_ids") == "0x"+strings.Repeat("b", 64)):
+		return response(http.StatusOK, "["+resolveMarket+"]"), nil
+	case req.URL.Path == "/markets":
+		return response(http.StatusOK, "[]"), nil
+	}
+	return response(http.StatusNotFound, `{"error":"not found"}`), nil
+}
+
+func TestParseInput(t *testing.T) {
+	condition := "0x" + strings.Repeat("Ab", 32)
+	tests := []struct {
+		input string
+		want  gamma.Reference
+	}{
+		{"https://polymarket.com/event/fed-decision", gamma.Reference{Kind: gamma.InputEventURL, EventSlug: "fed-decision"}},
+		{"polymarket.com/event/fed-decision/fed-cuts?tid=123", gamma.Reference{Kind: gamma.InputMarketURL, EventSlug: "fed-decision", MarketSlug: "fed-cuts"}},
+		{"https://www.polymarket.com/zh/event/fed-decision/", gamma.Reference{Kind: gamma.InputEventURL, EventSlug: "fed-decision"}},
+		{"https://polymarket.com/market/fed-cuts", gamma.Reference{Kind: gamma.InputMarketURL, MarketSlug: "fed-cuts"}},
+		{"https://polymarket.com/sports/nfl/games/week/1/nfl-dal-phi-2025-09-04", gamma.Reference{Kind: gamma.InputSportsURL, EventSlug: "nfl-dal-phi-2025-09-04"}},
+		{" fed-decision ", gamma.Reference{Kind: gamma.InputSlug, Slug: "fed-decision"}},
+		{"71321045679252212594626385532706912750332728571942532289631379312455583992563", gamma.Reference{Kind: gamma.InputTokenID, TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563"}},
+		{condition, gamma.Reference{Kind: gamma.InputConditionID, ConditionID: strings.ToLower(condition)}},
+	}
+	for _, tt := range tests {
+		got, err := gamma.ParseInput(tt.input)
+		if err != nil {
+			t.Errorf("ParseInput(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseInput(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "https://example.com/event/x", "https://polymarket.com/portfolio", "https://polymarket.com/sports/nba", "Not A Slug"} {
+		if ref, err := gamma.ParseInput(input); err == nil {
+			t.Errorf("ParseInput(%q) = %+v, want an error", input, ref)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	sdk := gamma.NewGammaSDK(&gamma.GammaSDKConfig{Transport: resolveServer{}})
+
+	res, err := sdk.Resolve("https://polymarket.com/event/fed-decision")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Event == nil || res.Event.ID != "1" || len(res.Markets) != 2 {
+		t.Fatalf("event resolution = %+v", res)
+	}
+	if ids := strings.Join(res.ConditionIDs(), ","); ids != "0xaa,0xbb" {
+		t.Errorf("ConditionIDs = %s", ids)
+	}
+	if ids := strings.Join(res.TokenIDs(), ","); ids != "101,102,111,112" {
+		t.Errorf("TokenIDs = %s", ids)
+	}
+
+	// Outcomes listed No first still give Yes its own token
+	for _, input := range []string{
+		"polymarket.com/event/fed-decision/fed-holds",
+		"https://polymarket.com/market/fed-holds",
+		"fed-holds",
+		"11200000000000000000000",
+		"0x" + strings.Repeat("b", 64),
+	} {
+		res, err := sdk.Resolve(input)
+		if err != nil {
+			t.Errorf("Resolve(%q): %v", input, err)
+			continue
+		}
+		if len(res.Markets) != 1 || res.Event == nil || res.Event.ID != "1" || len(res.Event.Markets) != 2 {
+			t.Errorf("Resolve(%q) = %+v", input, res)
+			continue
+		}
+		market := res.Markets[0]
+		if !market.Binary || market.Yes.TokenID != "112" || market.No.TokenID != "111" || market.Yes.Name != "Yes" {
+			t.Errorf("Resolve(%q) market = %+v", input, market)
+		}
+	}
+
+	res, err = sdk.Resolve("https://polymarket.com/sports/nba/nba-lal-gsw-2025-10-21")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if market := res.Markets[0]; market.Yes.Name != "Lakers" || market.No.TokenID != "202" {
+		t.Errorf("game market = %+v", market)
+	}
+	if len(res.Event.Series) != 1 || res.Event.Series[0].Slug != "nba" || res.Event.Series[0].Competitive == nil {
+		t.Errorf("game series = %+v", res.Event.Series)
+	}
+
+	for _, input := range []string{"no-such-slug", "12300000000000000000000", "https://polymarket.com/event/empty-event", "empty-event"} {
+		if _, err := sdk.Resolve(input); !errors.Is(err, gamma.ErrNotFound) {
+			t.Errorf("Resolve(%q) err = %v, want ErrNotFound", input, err)
+		}
+	}
+}
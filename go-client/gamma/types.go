@@ -104,32 +104,12 @@ type RelatedTagsQuery struct {
 	Ascending *bool   `json:"ascending,omitempty"`
 }
 
-// EventMarket represents a market within an event
-type EventMarket struct {
-	ID                 string   `json:"id"`
-	Question           string   `json:"question"`
-	ConditionID        string   `json:"conditionId"`
-	Slug               string   `json:"slug"`
-	ResolutionSource   *string  `json:"resolutionSource,omitempty"`
-	EndDate            *string  `json:"endDate,omitempty"`
-	Liquidity          *string  `json:"liquidity,omitempty"`
-	StartDate          *string  `json:"startDate,omitempty"`
-	Image              string   `json:"image"`
-	Icon               string   `json:"icon"`
-	Description        string   `json:"description"`
-	Outcomes           []string `json:"outcomes"`      // Parsed from JSON string
-	OutcomePrices      []string `json:"outcomePrices"` // Parsed from JSON string
-	Volume             *string  `json:"volume,omitempty"`
-	Active             bool     `json:"active"`
-	Closed             bool     `json:"closed"`
-	MarketMakerAddress *string  `json:"marketMakerAddress,omitempty"`
-	CreatedAt          string   `json:"createdAt"`
-	UpdatedAt          string   `json:"updatedAt"`
-	New                *bool    `json:"new,omitempty"`
-	ClobTokenIDs       []string `json:"clobTokenIds"` // Parsed from JSON string
-}
-
-// Event represents a collection of related markets
+// EventMarket represents a market within an event; the API returns the same model as for
+// markets, without Events
+type EventMarket = Market
+
+// Event represents a collection of related markets. Like Market, it parses numeric
+// strings and dates in any of the API's layouts.
 type Event struct {
 	ID                    string        `json:"id"`
 	Ticker                string        `json:"ticker"`
@@ -137,9 +117,9 @@ type Event struct {
 	Title                 string        `json:"title"`
 	Description           *string       `json:"description,omitempty"`
 	ResolutionSource      *string       `json:"resolutionSource,omitempty"`
-	StartDate             *string       `json:"startDate,omitempty"`
-	CreationDate          *string       `json:"creationDate,omitempty"`
-	EndDate               *string       `json:"endDate,omitempty"`
+	StartDate             time.Time     `json:"startDate"`
+	CreationDate          time.Time     `json:"creationDate"`
+	EndDate               time.Time     `json:"endDate"`
 	Image                 string        `json:"image"`
 	Icon                  string        `json:"icon"`
 	Active                bool          `json:"active"`
@@ -152,7 +132,17 @@ type Event struct {
 	Volume                *float64      `json:"volume,omitempty"`
 	Volume24hr            *float64      `json:"volume24hr,omitempty"`
 	VolumeNum             *float64      `json:"volumeNum,omitempty"`
-	LastActiveAt          *string       `json:"lastActiveAt,omitempty"`
+	Volume1wk             *float64      `json:"volume1wk,omitempty"`
+	Volume1mo             *float64      `json:"volume1mo,omitempty"`
+	Volume1yr             *float64      `json:"volume1yr,omitempty"`
+	OpenInterest          *float64      `json:"openInterest,omitempty"`
+	Competitive           *float64      `json:"competitive,omitempty"`
+	CommentCount          *int          `json:"commentCount,omitempty"`
+	NegRisk               *bool         `json:"negRisk,omitempty"`
+	NegRiskMarketID       *string       `json:"negRiskMarketID,omitempty"`
+	CreatedAt             time.Time     `json:"createdAt"`
+	UpdatedAt             time.Time     `json:"updatedAt"`
+	LastActiveAt          time.Time     `json:"lastActiveAt"`
 	LiquidityAmm          *float64      `json:"liquidityAmm,omitempty"`
 	LiquidityNum          *float64      `json:"liquidityNum,omitempty"`
 	Markets               []EventMarket `json:"markets"`
@@ -169,7 +159,7 @@ type Event struct {
 	PendingDeployment     *bool         `json:"pendingDeployment,omitempty"`
 	Deploying             *bool         `json:"deploying,omitempty"`
 	SortBy                *string       `json:"sortBy,omitempty"`
-	ClosedTime            *string       `json:"closedTime,omitempty"`
+	ClosedTime            time.Time     `json:"closedTime"`
 	AutomaticallyResolved *bool         `json:"automaticallyResolved,omitempty"`
 }
 
@@ -220,31 +210,130 @@ type EventByIdQuery struct {
 	IncludeChat *bool `json:"include_chat,omitempty"`
 }
 
-// Market represents a trading market
+// Market represents a trading market. Numeric fields the API sends as strings are
+// parsed, JSON-encoded arrays are decoded and dates are parsed in any of the API's
+// layouts; values that cannot be parsed are left zero.
 type Market struct {
-	ID               string   `json:"id"`
-	Question         string   `json:"question"`
-	ConditionID      string   `json:"conditionId"`
-	Slug             string   `json:"slug"`
-	Liquidity        *string  `json:"liquidity,omitempty"`
-	StartDate        *string  `json:"startDate,omitempty"`
-	Image            string   `json:"image"`
-	Icon             string   `json:"icon"`
-	Description      string   `json:"description"`
-	Active           bool     `json:"active"`
-	Volume           string   `json:"volume"`
-	Outcomes         []string `json:"outcomes"`      // Parsed from JSON string
-	OutcomePrices    []string `json:"outcomePrices"` // Parsed from JSON string
-	Closed           bool     `json:"closed"`
-	New              *bool    `json:"new,omitempty"`
-	QuestionID       *string  `json:"questionId,omitempty"`
-	VolumeNum        float64  `json:"volumeNum"`
-	LiquidityNum     *float64 `json:"liquidityNum,omitempty"`
-	StartDateIso     *string  `json:"startDateIso,omitempty"`
-	HasReviewedDates *bool    `json:"hasReviewedDates,omitempty"`
-	ClobTokenIDs     []string `json:"clobTokenIds"` // Parsed from JSON string
-	EndDate          *string  `json:"endDate,omitempty"`
-	LastActiveAt     *string  `json:"lastActiveAt,omitempty"`
+	ID                 string `json:"id"`
+	Question           string `json:"question"`
+	ConditionID        string `json:"conditionId"`
+	QuestionID         string `json:"questionID"`
+	Slug               string `json:"slug"`
+	Description        string `json:"description"`
+	ResolutionSource   string `json:"resolutionSource"`
+	Category           string `json:"category"`
+	MarketType         string `json:"marketType"`
+	Image              string `json:"image"`
+	Icon               string `json:"icon"`
+	MarketMakerAddress string `json:"marketMakerAddress"`
+	GroupItemTitle     string `json:"groupItemTitle"`
+	GroupItemThreshold string `json:"groupItemThreshold"`
+	SportsMarketType   string `json:"sportsMarketType"`
+	GameID             string `json:"gameId"`
+	ResolvedBy         string `json:"resolvedBy"`
+
+	// Outcome names, prices and CLOB token IDs share their order; see Outcomes
+	OutcomeNames  []string  `json:"outcomes"`
+	OutcomePrices []float64 `json:"outcomePrices"`
+	ClobTokenIDs  []string  `json:"clobTokenIds"`
+	ShortOutcomes []string  `json:"shortOutcomes,omitempty"`
+
+	// Status
+	Active           bool `json:"active"`
+	Closed           bool `json:"closed"`
+	Archived         bool `json:"archived"`
+	New              bool `json:"new"`
+	Featured         bool `json:"featured"`
+	Restricted       bool `json:"restricted"`
+	Ready            bool `json:"ready"`
+	Funded           bool `json:"funded"`
+	EnableOrderBook  bool `json:"enableOrderBook"`
+	AcceptingOrders  bool `json:"acceptingOrders"`
+	NegRisk          bool `json:"negRisk"`
+	NegRiskOther     bool `json:"negRiskOther"`
+	HasReviewedDates bool `json:"hasReviewedDates"`
+	CommentsEnabled  bool `json:"commentsEnabled"`
+	Cyom             bool `json:"cyom"`
+	RFQEnabled       bool `json:"rfqEnabled"`
+
+	NegRiskMarketID       string   `json:"negRiskMarketID"`
+	NegRiskRequestID      string   `json:"negRiskRequestID"`
+	UMAResolutionStatus   string   `json:"umaResolutionStatus"`
+	UMAResolutionStatuses []string `json:"umaResolutionStatuses,omitempty"`
+	UMABond               float64  `json:"umaBond"`
+	UMAReward             float64  `json:"umaReward"`
+
+	// Order book and prices
+	OrderPriceMinTickSize float64 `json:"orderPriceMinTickSize"`
+	OrderMinSize          float64 `json:"orderMinSize"`
+	BestBid               float64 `json:"bestBid"`
+	BestAsk               float64 `json:"bestAsk"`
+	Spread                float64 `json:"spread"`
+	LastTradePrice        float64 `json:"lastTradePrice"`
+	OneHourPriceChange    float64 `json:"oneHourPriceChange"`
+	OneDayPriceChange     float64 `json:"oneDayPriceChange"`
+	OneWeekPriceChange    float64 `json:"oneWeekPriceChange"`
+	OneMonthPriceChange   float64 `json:"oneMonthPriceChange"`
+	MakerBaseFee          float64 `json:"makerBaseFee"`
+	TakerBaseFee          float64 `json:"takerBaseFee"`
+
+	// Liquidity and volume in USDC
+	Liquidity      float64 `json:"liquidity"`
+	LiquidityNum   float64 `json:"liquidityNum"`
+	LiquidityClob  float64 `json:"liquidityClob"`
+	LiquidityAmm   float64 `json:"liquidityAmm"`
+	Volume         float64 `json:"volume"`
+	VolumeNum      float64 `json:"volumeNum"`
+	VolumeClob     float64 `json:"volumeClob"`
+	VolumeAmm      float64 `json:"volumeAmm"`
+	Volume24hr     float64 `json:"volume24hr"`
+	Volume24hrClob float64 `json:"volume24hrClob"`
+	Volume1wk      float64 `json:"volume1wk"`
+	Volume1mo      float64 `json:"volume1mo"`
+	Volume1yr      float64 `json:"volume1yr"`
+	Competitive    float64 `json:"competitive"`
+
+	// Liquidity rewards
+	RewardsMinSize   float64        `json:"rewardsMinSize"`
+	RewardsMaxSpread float64        `json:"rewardsMaxSpread"`
+	ClobRewards      []MarketReward `json:"clobRewards,omitempty"`
+
+	// Dates
+	StartDate                time.Time `json:"startDate"`
+	EndDate                  time.Time `json:"endDate"`
+	StartDateIso             time.Time `json:"startDateIso"`
+	EndDateIso               time.Time `json:"endDateIso"`
+	UMAEndDate               time.Time `json:"umaEndDate"`
+	ClosedTime               time.Time `json:"closedTime"`
+	GameStartTime            time.Time `json:"gameStartTime"`
+	EventStartTime           time.Time `json:"eventStartTime"`
+	AcceptingOrdersTimestamp time.Time `json:"acceptingOrdersTimestamp"`
+	CreatedAt                time.Time `json:"createdAt"`
+	UpdatedAt                time.Time `json:"updatedAt"`
+	LastActiveAt             time.Time `json:"lastActiveAt"`
+
+	// Events holds the parent event on market endpoints; it is empty for markets nested
+	// in an Event
+	Events []Event `json:"events,omitempty"`
+	Tags   []Tag   `json:"tags,omitempty"`
+}
+
+// MarketReward is a liquidity reward program of a market
+type MarketReward struct {
+	ID               string    `json:"id"`
+	ConditionID      string    `json:"conditionId"`
+	AssetAddress     string    `json:"assetAddress"`
+	RewardsAmount    float64   `json:"rewardsAmount"`
+	RewardsDailyRate float64   `json:"rewardsDailyRate"`
+	StartDate        time.Time `json:"startDate"`
+	EndDate          time.Time `json:"endDate"`
+}
+
+// MarketOutcome pairs an outcome with its CLOB token and price
+type MarketOutcome struct {
+	Name    string
+	TokenID string
+	Price   float64
 }
 
 // UpdatedMarketQuery represents query parameters for markets
@@ -0,0 +1,465 @@
+// Package httprecord records HTTP exchanges to fixture files and replays them, so
+// ClobClient, GammaSDK and DataSDK can be tested offline against real payloads.
+//
+// A Recorder is an http.RoundTripper; pass it as the Transport of any client config:
+//
+//	rec, err := httprecord.New(httprecord.Config{Path: "testdata/markets.json", Mode: httprecord.ModeFromEnv()})
+//	gammaSDK := gamma.NewGammaSDK(&gamma.GammaSDKConfig{Transport: rec})
+//
+// Secrets (POLY_SIGNATURE, POLY_API_KEY, passphrases, API secrets) are redacted before
+// fixtures are written, including any other place the same values appear.
+package httprecord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder talks to the network
+type Mode int
+
+const (
+	// ModeReplay serves responses from the fixture file only; unmatched requests fail
+	ModeReplay Mode = iota
+	// ModeRecord sends every request to the network and rewrites the fixture file
+	ModeRecord
+	// ModeReplayOrRecord replays matching requests and records the rest
+	ModeReplayOrRecord
+)
+
+// Redacted replaces secret values in fixtures
+const Redacted = "REDACTED"
+
+// RecordEnv is the environment variable read by ModeFromEnv
+const RecordEnv = "HTTPRECORD"
+
+// ModeFromEnv returns the mode named by $HTTPRECORD ("record" or "auto"), and ModeReplay
+// otherwise, so CI replays by default and fixtures are refreshed with HTTPRECORD=record
+func ModeFromEnv() Mode {
+	switch os.Getenv(RecordEnv) {
+	case "record":
+		return ModeRecord
+	case "auto":
+		return ModeReplayOrRecord
+	default:
+		return ModeReplay
+	}
+}
+
+// DefaultRedactedHeaders are the headers whose values never reach a fixture
+var DefaultRedactedHeaders = []string{
+	"POLY_SIGNATURE",
+	"POLY_API_KEY",
+	"POLY_PASSPHRASE",
+	"POLY_BUILDER_SIGNATURE",
+	"POLY_BUILDER_API_KEY",
+	"POLY_BUILDER_PASSPHRASE",
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// DefaultRedactedFields are the JSON body fields whose values never reach a fixture,
+// at any depth (e.g. the credentials returned by /auth/api-key)
+var DefaultRedactedFields = []string{
+	"apiKey",
+	"secret",
+	"passphrase",
+}
+
+// Config configures a Recorder
+type Config struct {
+	// Path is the fixture file
+	Path string
+
+	// Mode defaults to ModeReplay
+	Mode Mode
+
+	// Transport sends recorded requests (default http.DefaultTransport)
+	Transport http.RoundTripper
+
+	// RedactHeaders and RedactFields extend the default redaction lists
+	RedactHeaders []string
+	RedactFields  []string
+
+	// IgnoreQuery lists query parameters left out of replay matching (e.g. timestamps)
+	IgnoreQuery []string
+}
+
+// Interaction is one recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the request half of an Interaction
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response half of an Interaction
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a request or response body. JSON objects and arrays are stored inline so
+// fixtures stay readable and diffable; anything else is stored as a string.
+type Body []byte
+
+// MarshalJSON stores JSON objects and arrays as is and other bodies as strings
+func (b Body) MarshalJSON() ([]byte, error) {
+	if len(b) == 0 {
+		return []byte(`""`), nil
+	}
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(b) {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, b); err == nil {
+			return compact.Bytes(), nil
+		}
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON restores a body written by MarshalJSON; inline JSON is compacted again,
+// undoing the fixture file's indentation
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = Body(text)
+		return nil
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return err
+	}
+	*b = compact.Bytes()
+	return nil
+}
+
+type fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records to or replays from a fixture file
+type Recorder struct {
+	config        Config
+	redactHeaders map[string]bool
+	redactFields  map[string]bool
+	ignoreQuery   map[string]bool
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	// replayed counts the responses served per match key; repeated requests are
+	// answered in recorded order and the last response is repeated after that
+	replayed map[string]int
+	// secrets are redacted values seen so far, scrubbed from every body
+	secrets map[string]bool
+}
+
+// New creates a Recorder, loading the fixture file unless recording from scratch
+func New(config Config) (*Recorder, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("fixture path is required")
+	}
+	if config.Transport == nil {
+		config.Transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		config:        config,
+		redactHeaders: make(map[string]bool),
+		redactFields:  make(map[string]bool),
+		ignoreQuery:   make(map[string]bool),
+		replayed:      make(map[string]int),
+		secrets:       make(map[string]bool),
+	}
+	for _, header := range append(DefaultRedactedHeaders, config.RedactHeaders...) {
+		r.redactHeaders[http.CanonicalHeaderKey(header)] = true
+	}
+	for _, field := range append(DefaultRedactedFields, config.RedactFields...) {
+		r.redactFields[field] = true
+	}
+	for _, name := range config.IgnoreQuery {
+		r.ignoreQuery[name] = true
+	}
+
+	if config.Mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(config.Path)
+	if err != nil {
+		if os.IsNotExist(err) && config.Mode == ModeReplayOrRecord {
+			return r, nil
+		}
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", config.Path, err)
+	}
+	r.interactions = f.Interactions
+
+	return r, nil
+}
+
+// Client returns an http.Client using the Recorder as its transport
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions recorded or loaded so far
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]Interaction, len(r.interactions))
+	for i, interaction := range r.interactions {
+		result[i] = *interaction
+	}
+	return result
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.config.Mode != ModeRecord {
+		if resp := r.replay(req); resp != nil {
+			return resp, nil
+		}
+		if r.config.Mode == ModeReplay {
+			return nil, fmt.Errorf("httprecord: no recorded response for %s in %s", r.matchKey(req.Method, req.URL), r.config.Path)
+		}
+	}
+	return r.record(req)
+}
+
+// replay returns the next recorded response matching req, or nil
+func (r *Recorder) replay(req *http.Request) *http.Response {
+	key := r.matchKey(req.Method, req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matches []*Interaction
+	for _, interaction := range r.interactions {
+		recorded, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			continue
+		}
+		if r.matchKey(interaction.Request.Method, recorded) == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	n := r.replayed[key]
+	r.replayed[key] = n + 1
+	if n >= len(matches) {
+		n = len(matches) - 1
+	}
+	recorded := matches[n].Response
+
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+// record sends req over the network and appends the redacted exchange to the fixture
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.config.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: r.redactHeader(req.Header),
+			Body:   r.redactBody(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     r.redactHeader(resp.Header),
+			Body:       r.redactBody(respBody),
+		},
+	}
+	// Secrets found in the response (e.g. a new API key) may already have been written
+	// into earlier parts of this exchange
+	interaction.Request.Body = r.scrub(interaction.Request.Body)
+	r.interactions = append(r.interactions, interaction)
+
+	if err := r.save(); err != nil {
+		return nil, fmt.Errorf("httprecord: failed to write fixture: %w", err)
+	}
+	return resp, nil
+}
+
+// save writes every interaction to the fixture file atomically. The caller must hold r.mu.
+func (r *Recorder) save() error {
+	// Earlier interactions may contain secrets that were only identified later
+	for _, interaction := range r.interactions {
+		interaction.Request.Body = r.scrub(interaction.Request.Body)
+		interaction.Response.Body = r.scrub(interaction.Response.Body)
+	}
+
+	data, err := json.MarshalIndent(fixture{Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.config.Path), 0755); err != nil {
+		return err
+	}
+	tmp := r.config.Path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.config.Path)
+}
+
+// matchKey identifies a request for replay: method, path and the query with sorted keys
+// and without ignored parameters. The host is left out so fixtures work against any base URL.
+func (r *Recorder) matchKey(method string, u *url.URL) string {
+	query := u.Query()
+	for name := range r.ignoreQuery {
+		query.Del(name)
+	}
+
+	key := strings.ToUpper(method) + " " + u.Path
+	if encoded := query.Encode(); encoded != "" {
+		key += "?" + encoded
+	}
+	return key
+}
+
+// redactHeader copies header with secret values replaced. The caller must hold r.mu.
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	result := header.Clone()
+	for name, values := range result {
+		if !r.redactHeaders[http.CanonicalHeaderKey(name)] {
+			continue
+		}
+		for i, value := range values {
+			r.addSecret(value)
+			values[i] = Redacted
+		}
+	}
+	return result
+}
+
+// redactBody replaces secret JSON fields and every known secret value. The caller must hold r.mu.
+func (r *Recorder) redactBody(body []byte) Body {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err == nil {
+		if r.redactValue(value) {
+			if redacted, err := json.Marshal(value); err == nil {
+				body = redacted
+			}
+		}
+	}
+	return r.scrub(body)
+}
+
+// redactValue redacts secret fields of a decoded JSON value in place and reports
+// whether anything changed
+func (r *Recorder) redactValue(value interface{}) bool {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if r.redactFields[key] {
+				if text, ok := field.(string); ok {
+					r.addSecret(text)
+				}
+				v[key] = Redacted
+				changed = true
+				continue
+			}
+			if r.redactValue(field) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if r.redactValue(item) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// scrub replaces every occurrence of a known secret, e.g. an API key echoed back as the
+// order owner. The caller must hold r.mu.
+func (r *Recorder) scrub(body Body) Body {
+	for secret := range r.secrets {
+		if bytes.Contains(body, []byte(secret)) {
+			body = bytes.ReplaceAll(body, []byte(secret), []byte(Redacted))
+		}
+	}
+	return body
+}
+
+// addSecret remembers a redacted value; short values are skipped to avoid scrubbing
+// unrelated text
+func (r *Recorder) addSecret(value string) {
+	if len(value) >= 8 && value != Redacted {
+		r.secrets[value] = true
+	}
+}
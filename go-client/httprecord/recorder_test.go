@@ -0,0 +1,91 @@
+package httprecord_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/httprecord"
+)
+
+func TestRecordRedactReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/api-key":
+			io.WriteString(w, `{"apiKey":"key-1234567890","secret":"c2VjcmV0LXNlY3JldA==","passphrase":"pass-1234567890"}`)
+		case "/data/orders":
+			io.WriteString(w, `{"data":[{"owner":"key-1234567890"}],"next_cursor":"LTE="}`)
+		case "/ok":
+			io.WriteString(w, `"OK"`)
+		default:
+			io.WriteString(w, `[{"page":"`+r.URL.Query().Get("offset")+`"}]`)
+		}
+	}))
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	rec, err := httprecord.New(httprecord.Config{Path: path, Mode: httprecord.ModeRecord})
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient := rec.Client()
+
+	get(t, httpClient, srv.URL+"/auth/api-key", nil)
+	get(t, httpClient, srv.URL+"/data/orders", http.Header{"POLY_API_KEY": {"key-1234567890"}, "POLY_SIGNATURE": {"sig-1234567890"}})
+	get(t, httpClient, srv.URL+"/events?offset=0&limit=2", nil)
+	get(t, httpClient, srv.URL+"/ok", nil)
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"key-1234567890", "c2VjcmV0LXNlY3JldA==", "pass-1234567890", "sig-1234567890"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("fixture leaks %q:\n%s", secret, data)
+		}
+	}
+
+	replay, err := httprecord.New(httprecord.Config{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient = replay.Client()
+
+	// Query order and host do not matter when replaying
+	if body := get(t, httpClient, "https://example.com/events?limit=2&offset=0", nil); body != `[{"page":"0"}]` {
+		t.Fatalf("unexpected replay body %s", body)
+	}
+	if body := get(t, httpClient, "https://example.com/ok", nil); body != `"OK"` {
+		t.Fatalf("unexpected replay body %s", body)
+	}
+	if _, err := httpClient.Get("https://example.com/events?offset=2&limit=2"); err == nil {
+		t.Fatal("expected unmatched request to fail")
+	}
+}
+
+func get(t *testing.T, httpClient *http.Client, url string, header http.Header) string {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
@@ -0,0 +1,281 @@
+// Package querystring encodes query structs into URL query parameters for GammaSDK and
+// DataSDK.
+//
+// Parameter names come from the `json` tag, or from a `query` tag that overrides it.
+// The `query` tag also takes options after the name:
+//
+//	Slug    []string   `json:"slug,omitempty"`                      // slug=a&slug=b
+//	Market  *[]string  `json:"market,omitempty" query:",comma"`     // market=a,b
+//	EndDate *time.Time `json:"end_date,omitempty" query:",date"`    // end_date=2024-01-31
+//
+// Nil pointers, empty slices and (with omitempty) zero values are left out. Slices and
+// arrays become repeated parameters, or one comma-joined parameter with the comma option.
+// time.Time is formatted as RFC 3339 (or YYYY-MM-DD with the date option), big.Int in
+// decimal, and types implementing QueryMarshaler encode themselves.
+package querystring
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryMarshaler is implemented by types that encode themselves as query values. Each
+// returned value is a repeated parameter, or part of the comma-joined one.
+type QueryMarshaler interface {
+	MarshalQuery() ([]string, error)
+}
+
+var (
+	queryMarshalerType = reflect.TypeOf((*QueryMarshaler)(nil)).Elem()
+	textMarshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType           = reflect.TypeOf(time.Time{})
+	bigIntType         = reflect.TypeOf(big.Int{})
+)
+
+// fieldOptions are the options of one struct field
+type fieldOptions struct {
+	name      string
+	omitempty bool
+	comma     bool
+	date      bool
+}
+
+// Values encodes a query struct, or a pointer to one, into URL query parameters. A nil
+// pointer gives no parameters.
+func Values(query interface{}) (url.Values, error) {
+	values := url.Values{}
+	if query == nil {
+		return values, nil
+	}
+
+	v := reflect.ValueOf(query)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("querystring: expected a struct, got %s", v.Type())
+	}
+
+	if err := encodeStruct(values, v); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Encode encodes a query struct as Values does and returns the encoded query string
+func Encode(query interface{}) (string, error) {
+	values, err := Values(query)
+	if err != nil {
+		return "", err
+	}
+	return values.Encode(), nil
+}
+
+func encodeStruct(values url.Values, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+
+		// Embedded structs contribute their fields
+		if field.Anonymous && field.Tag.Get("json") == "" && field.Tag.Get("query") == "" {
+			for fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					break
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				if err := encodeStruct(values, fieldValue); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		options, ok := parseTags(field)
+		if !ok {
+			continue
+		}
+		if options.omitempty && fieldValue.IsZero() {
+			continue
+		}
+
+		items, err := encodeValue(fieldValue, options)
+		if err != nil {
+			return fmt.Errorf("querystring: field %s: %w", field.Name, err)
+		}
+		if len(items) == 0 {
+			continue
+		}
+		if options.comma {
+			values.Add(options.name, strings.Join(items, ","))
+			continue
+		}
+		for _, item := range items {
+			values.Add(options.name, item)
+		}
+	}
+	return nil
+}
+
+// parseTags reads the field's name and options; fields without a name, or tagged "-",
+// are skipped
+func parseTags(field reflect.StructField) (fieldOptions, bool) {
+	var options fieldOptions
+
+	jsonTag := field.Tag.Get("json")
+	if jsonTag == "-" {
+		return options, false
+	}
+	jsonParts := strings.Split(jsonTag, ",")
+	options.name = jsonParts[0]
+	for _, part := range jsonParts[1:] {
+		if part == "omitempty" {
+			options.omitempty = true
+		}
+	}
+
+	if queryTag, ok := field.Tag.Lookup("query"); ok {
+		if queryTag == "-" {
+			return options, false
+		}
+		queryParts := strings.Split(queryTag, ",")
+		if queryParts[0] != "" {
+			options.name = queryParts[0]
+		}
+		for _, part := range queryParts[1:] {
+			switch part {
+			case "omitempty":
+				options.omitempty = true
+			case "comma":
+				options.comma = true
+			case "date":
+				options.date = true
+			}
+		}
+	}
+
+	return options, options.name != ""
+}
+
+// encodeValue returns the query values of v: none for nil pointers and empty slices,
+// one per element for slices and arrays, one otherwise
+func encodeValue(v reflect.Value, options fieldOptions) ([]string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Implements(queryMarshalerType) {
+			break
+		}
+		v = v.Elem()
+	}
+
+	if marshaler, ok := asQueryMarshaler(v); ok {
+		return marshaler.MarshalQuery()
+	}
+
+	switch {
+	case v.Type() == timeType:
+		t := v.Interface().(time.Time)
+		if options.date {
+			return []string{t.Format(time.DateOnly)}, nil
+		}
+		return []string{t.Format(time.RFC3339)}, nil
+	case v.Type() == bigIntType:
+		return []string{addressable(v).Addr().Interface().(*big.Int).String()}, nil
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		// []byte is a string, not a list of numbers
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return []string{string(v.Bytes())}, nil
+		}
+		items := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, err := encodeValue(v.Index(i), options)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item...)
+		}
+		return items, nil
+	}
+
+	item, err := encodeScalar(v)
+	if err != nil {
+		return nil, err
+	}
+	return []string{item}, nil
+}
+
+func encodeScalar(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+
+	if marshaler, ok := asTextMarshaler(v); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(text), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// asQueryMarshaler returns v's QueryMarshaler, also when only *T implements it
+func asQueryMarshaler(v reflect.Value) (QueryMarshaler, bool) {
+	if v.Type().Implements(queryMarshalerType) {
+		return v.Interface().(QueryMarshaler), true
+	}
+	if reflect.PointerTo(v.Type()).Implements(queryMarshalerType) {
+		return addressable(v).Addr().Interface().(QueryMarshaler), true
+	}
+	return nil, false
+}
+
+// asTextMarshaler returns v's encoding.TextMarshaler, also when only *T implements it
+func asTextMarshaler(v reflect.Value) (encoding.TextMarshaler, bool) {
+	if v.Type().Implements(textMarshalerType) {
+		return v.Interface().(encoding.TextMarshaler), true
+	}
+	if reflect.PointerTo(v.Type()).Implements(textMarshalerType) {
+		return addressable(v).Addr().Interface().(encoding.TextMarshaler), true
+	}
+	return nil, false
+}
+
+// addressable returns v, or a copy of it that can be addressed
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+	return copied
+}
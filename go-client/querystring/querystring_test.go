@@ -0,0 +1,117 @@
+package querystring_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/data"
+	"github.com/HuakunShen/polymarket-kit/go-client/gamma"
+	"github.com/HuakunShen/polymarket-kit/go-client/querystring"
+)
+
+// outcomeIndexes encodes itself as "o<index>" values
+type outcomeIndexes []int
+
+func (o outcomeIndexes) MarshalQuery() ([]string, error) {
+	values := make([]string, len(o))
+	for i, index := range o {
+		values[i] = "o" + strings.Repeat("I", index)
+	}
+	return values, nil
+}
+
+func TestEncode(t *testing.T) {
+	limit := 10
+	closed := false
+	ids := []string{"0xa", "0xb"}
+	when := time.Date(2024, 1, 31, 12, 30, 0, 0, time.UTC)
+
+	type base struct {
+		Offset int `json:"offset"`
+	}
+	query := struct {
+		base
+		Limit      *int           `json:"limit,omitempty"`
+		Closed     *bool          `json:"closed,omitempty"`
+		Order      string         `json:"order,omitempty"`
+		Slug       []string       `json:"slug,omitempty"`
+		Market     *[]string      `json:"market,omitempty" query:",comma"`
+		ID         []big.Int      `json:"id,omitempty"`
+		Total      *big.Int       `json:"total,omitempty"`
+		After      time.Time      `json:"after,omitempty"`
+		EndDate    *time.Time     `json:"end,omitempty" query:"end_date,date"`
+		Outcomes   outcomeIndexes `json:"outcomes,omitempty" query:",comma"`
+		Price      float64        `json:"price"`
+		Skipped    string         `json:"-"`
+		Untagged   string
+		Empty      []string    `json:"empty"`
+		NilPointer *[]string   `json:"nil_pointer"`
+		Any        interface{} `json:"any,omitempty"`
+	}{
+		base:     base{Offset: 20},
+		Limit:    &limit,
+		Closed:   &closed,
+		Slug:     []string{"a", "b"},
+		Market:   &ids,
+		ID:       []big.Int{*big.NewInt(1), *big.NewInt(2)},
+		Total:    new(big.Int).Lsh(big.NewInt(1), 70),
+		After:    when,
+		EndDate:  &when,
+		Outcomes: outcomeIndexes{1, 2},
+		Price:    0.5,
+		Skipped:  "x",
+		Untagged: "y",
+		Any:      []int{3, 4},
+	}
+
+	got, err := querystring.Encode(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "after=2024-01-31T12%3A30%3A00Z&any=3&any=4&closed=false&end_date=2024-01-31&id=1&id=2&limit=10" +
+		"&market=0xa%2C0xb&offset=20&outcomes=oI%2CoII&price=0.5&slug=a&slug=b&total=1180591620717411303424"
+	if got != want {
+		t.Errorf("Encode =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestEncodeNilAndInvalid(t *testing.T) {
+	var query *data.PositionsQuery
+	if got, err := querystring.Encode(query); err != nil || got != "" {
+		t.Errorf("nil query: %q, %v", got, err)
+	}
+	if _, err := querystring.Encode([]string{"a"}); err == nil {
+		t.Error("expected an error for a non-struct query")
+	}
+
+	unsupported := struct {
+		Filter map[string]string `json:"filter"`
+	}{Filter: map[string]string{"a": "b"}}
+	if _, err := querystring.Encode(unsupported); err == nil {
+		t.Error("expected an error for a map field")
+	}
+}
+
+func TestEncodeSDKQueries(t *testing.T) {
+	got, err := querystring.Encode(&gamma.UpdatedMarketQuery{
+		ConditionIDs: []string{"0xa", "0xb"},
+		ID:           []big.Int{*big.NewInt(7)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "condition_ids=0xa&condition_ids=0xb") || !strings.Contains(got, "id=7") {
+		t.Errorf("gamma markets query = %s", got)
+	}
+
+	markets := []string{"0xa", "0xb"}
+	got, err = querystring.Encode(&data.PositionsQuery{Market: &markets})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "market=0xa%2C0xb" {
+		t.Errorf("data positions query = %s", got)
+	}
+}
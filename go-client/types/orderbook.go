@@ -0,0 +1,36 @@
+package types
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// OrderBookHash computes the hash the CLOB attaches to order books: the SHA-1 of the
+// compact JSON summary with an empty hash field (as in py-clob-client). It is not yet
+// verified against hashes recorded from the live CLOB.
+func OrderBookHash(book *OrderBookSummary) string {
+	data, _ := json.Marshal(struct {
+		Market       string         `json:"market"`
+		AssetID      string         `json:"asset_id"`
+		Timestamp    string         `json:"timestamp"`
+		Bids         []OrderSummary `json:"bids"`
+		Asks         []OrderSummary `json:"asks"`
+		MinOrderSize string         `json:"min_order_size"`
+		NegRisk      bool           `json:"neg_risk"`
+		TickSize     string         `json:"tick_size"`
+		Hash         string         `json:"hash"`
+	}{
+		Market:       book.Market,
+		AssetID:      book.AssetID,
+		Timestamp:    book.Timestamp,
+		Bids:         book.Bids,
+		Asks:         book.Asks,
+		MinOrderSize: book.MinOrderSize,
+		NegRisk:      book.NegRisk,
+		TickSize:     book.TickSize,
+	})
+
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
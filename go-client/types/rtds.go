@@ -0,0 +1,214 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Real-time data service (RTDS) message types
+// Based on: https://docs.polymarket.com/developers/RTDS/RTDS-overview
+
+// RTDSTopic is an RTDS topic
+type RTDSTopic string
+
+const (
+	// RTDSTopicActivity streams trades across all markets
+	RTDSTopicActivity RTDSTopic = "activity"
+	// RTDSTopicComments streams comments and reactions on events and series
+	RTDSTopicComments RTDSTopic = "comments"
+	// RTDSTopicCryptoPrices streams spot crypto prices
+	RTDSTopicCryptoPrices RTDSTopic = "crypto_prices"
+)
+
+// RTDSMessageType is the type of an RTDS message within its topic
+type RTDSMessageType string
+
+const (
+	// RTDSTypeAll subscribes to every type of a topic
+	RTDSTypeAll RTDSMessageType = "*"
+
+	RTDSTypeTrades        RTDSMessageType = "trades"
+	RTDSTypeOrdersMatched RTDSMessageType = "orders_matched"
+
+	RTDSTypeCommentCreated  RTDSMessageType = "comment_created"
+	RTDSTypeCommentRemoved  RTDSMessageType = "comment_removed"
+	RTDSTypeReactionCreated RTDSMessageType = "reaction_created"
+	RTDSTypeReactionRemoved RTDSMessageType = "reaction_removed"
+
+	RTDSTypeUpdate RTDSMessageType = "update"
+)
+
+// RTDSSubscription selects the messages of one topic and type. Filters is the
+// topic-specific filter the server applies, a JSON object encoded as a string for
+// activity and comments (see ActivityFilter and CommentsFilter).
+type RTDSSubscription struct {
+	Topic   RTDSTopic       `json:"topic"`
+	Type    RTDSMessageType `json:"type"`
+	Filters string          `json:"filters,omitempty"`
+}
+
+// ActivityFilter returns the Filters of an activity subscription limited to one event or
+// market slug; empty slugs are left out
+func ActivityFilter(eventSlug string, marketSlug string) string {
+	filter := make(map[string]string)
+	if eventSlug != "" {
+		filter["event_slug"] = eventSlug
+	}
+	if marketSlug != "" {
+		filter["market_slug"] = marketSlug
+	}
+	if len(filter) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(filter)
+	return string(data)
+}
+
+// CommentsFilter returns the Filters of a comments subscription limited to one parent
+// entity, e.g. ("Event", 12345)
+func CommentsFilter(parentEntityType string, parentEntityID int64) string {
+	data, _ := json.Marshal(map[string]interface{}{
+		"parentEntityType": parentEntityType,
+		"parentEntityID":   parentEntityID,
+	})
+	return string(data)
+}
+
+// RTDSMessage is the envelope of every RTDS message; Payload depends on Topic and Type
+type RTDSMessage struct {
+	Topic        RTDSTopic       `json:"topic"`
+	Type         RTDSMessageType `json:"type"`
+	Timestamp    int64           `json:"timestamp"`
+	ConnectionID string          `json:"connection_id"`
+	Payload      json.RawMessage `json:"payload"`
+}
+
+// ActivityTrade is the payload of activity trades and orders_matched messages
+type ActivityTrade struct {
+	Asset           string  `json:"asset"`
+	ConditionID     string  `json:"conditionId"`
+	EventSlug       string  `json:"eventSlug"`
+	Slug            string  `json:"slug"`
+	Title           string  `json:"title"`
+	Icon            string  `json:"icon"`
+	Outcome         string  `json:"outcome"`
+	OutcomeIndex    int     `json:"outcomeIndex"`
+	Side            Side    `json:"side"`
+	Price           float64 `json:"price"`
+	Size            float64 `json:"size"`
+	Timestamp       int64   `json:"timestamp"`
+	TransactionHash string  `json:"transactionHash"`
+	ProxyWallet     string  `json:"proxyWallet"`
+	Name            string  `json:"name"`
+	Pseudonym       string  `json:"pseudonym"`
+	Bio             string  `json:"bio"`
+	ProfileImage    string  `json:"profileImage"`
+}
+
+// Notional returns the trade's value in USDC, price times size
+func (t *ActivityTrade) Notional() float64 {
+	return t.Price * t.Size
+}
+
+// CommentProfile is the author profile attached to a comment
+type CommentProfile struct {
+	Name                  string `json:"name"`
+	Pseudonym             string `json:"pseudonym"`
+	DisplayUsernamePublic bool   `json:"displayUsernamePublic"`
+	Bio                   string `json:"bio"`
+	ProxyWallet           string `json:"proxyWallet"`
+	ProfileImage          string `json:"profileImage"`
+}
+
+// Comment is the payload of comment_created and comment_removed messages
+type Comment struct {
+	ID               string          `json:"id"`
+	Body             string          `json:"body"`
+	ParentEntityType string          `json:"parentEntityType"`
+	ParentEntityID   int64           `json:"parentEntityID"`
+	ParentCommentID  string          `json:"parentCommentID"`
+	UserAddress      string          `json:"userAddress"`
+	ReplyAddress     string          `json:"replyAddress"`
+	ReactionCount    int             `json:"reactionCount"`
+	ReportCount      int             `json:"reportCount"`
+	CreatedAt        string          `json:"createdAt"`
+	UpdatedAt        string          `json:"updatedAt"`
+	Profile          *CommentProfile `json:"profile,omitempty"`
+}
+
+// Reaction is the payload of reaction_created and reaction_removed messages
+type Reaction struct {
+	ID           string `json:"id"`
+	CommentID    int64  `json:"commentID"`
+	ReactionType string `json:"reactionType"`
+	Icon         string `json:"icon"`
+	UserAddress  string `json:"userAddress"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+// CryptoPrice is the payload of crypto_prices update messages
+type CryptoPrice struct {
+	Symbol    string  `json:"symbol"`
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// ParseRTDSMessage parses an RTDS message envelope
+func ParseRTDSMessage(data []byte) (*RTDSMessage, error) {
+	var msg RTDSMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse RTDS message: %w", err)
+	}
+	if msg.Topic == "" {
+		return nil, fmt.Errorf("topic is required")
+	}
+	return &msg, nil
+}
+
+// ActivityTrade decodes the payload of an activity trade message
+func (m *RTDSMessage) ActivityTrade() (*ActivityTrade, error) {
+	if m.Topic != RTDSTopicActivity {
+		return nil, fmt.Errorf("not an activity message: %s/%s", m.Topic, m.Type)
+	}
+	var trade ActivityTrade
+	if err := json.Unmarshal(m.Payload, &trade); err != nil {
+		return nil, fmt.Errorf("failed to parse activity trade: %w", err)
+	}
+	return &trade, nil
+}
+
+// Comment decodes the payload of a comment_created or comment_removed message
+func (m *RTDSMessage) Comment() (*Comment, error) {
+	if m.Topic != RTDSTopicComments || (m.Type != RTDSTypeCommentCreated && m.Type != RTDSTypeCommentRemoved) {
+		return nil, fmt.Errorf("not a comment message: %s/%s", m.Topic, m.Type)
+	}
+	var comment Comment
+	if err := json.Unmarshal(m.Payload, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment: %w", err)
+	}
+	return &comment, nil
+}
+
+// Reaction decodes the payload of a reaction_created or reaction_removed message
+func (m *RTDSMessage) Reaction() (*Reaction, error) {
+	if m.Topic != RTDSTopicComments || (m.Type != RTDSTypeReactionCreated && m.Type != RTDSTypeReactionRemoved) {
+		return nil, fmt.Errorf("not a reaction message: %s/%s", m.Topic, m.Type)
+	}
+	var reaction Reaction
+	if err := json.Unmarshal(m.Payload, &reaction); err != nil {
+		return nil, fmt.Errorf("failed to parse reaction: %w", err)
+	}
+	return &reaction, nil
+}
+
+// CryptoPrice decodes the payload of a crypto_prices message
+func (m *RTDSMessage) CryptoPrice() (*CryptoPrice, error) {
+	if m.Topic != RTDSTopicCryptoPrices {
+		return nil, fmt.Errorf("not a crypto price message: %s/%s", m.Topic, m.Type)
+	}
+	var price CryptoPrice
+	if err := json.Unmarshal(m.Payload, &price); err != nil {
+		return nil, fmt.Errorf("failed to parse crypto price: %w", err)
+	}
+	return &price, nil
+}
@@ -35,15 +35,31 @@ const (
 type SignatureType int
 
 const (
+	// SignatureTypeEIP712 is a plain EOA signature where the signer is also the maker
 	SignatureTypeEIP712 SignatureType = 0
+	// SignatureTypePolyProxy is used when the maker is a Polymarket proxy wallet owned by the signer
+	SignatureTypePolyProxy SignatureType = 1
+	// SignatureTypePolyGnosisSafe is used when the maker is a Gnosis Safe owned by the signer
+	SignatureTypePolyGnosisSafe SignatureType = 2
+
+	// SignatureTypeEthSign shares its value with SignatureTypePolyGnosisSafe.
+	//
+	// Deprecated: use SignatureTypePolyGnosisSafe.
 	SignatureTypeEthSign SignatureType = 2
 )
 
+// RequiresFunder reports whether orders signed with this type need a separate funder (maker) address
+func (s SignatureType) RequiresFunder() bool {
+	return s == SignatureTypePolyProxy || s == SignatureTypePolyGnosisSafe
+}
+
 // ApiKeyCreds represents API key credentials
 type ApiKeyCreds struct {
 	Key        string `json:"key"`
 	Secret     string `json:"secret"`
 	Passphrase string `json:"passphrase"`
+	// Nonce is the L1 nonce the key was created with, needed to derive it again
+	Nonce uint64 `json:"nonce,omitempty"`
 }
 
 // ApiKeyRaw represents raw API key response
@@ -279,6 +295,12 @@ type Notification struct {
 	Payload interface{} `json:"payload"`
 }
 
+// CancelOrdersResponse represents the result of a cancel request
+type CancelOrdersResponse struct {
+	Canceled    []string          `json:"canceled"`
+	NotCanceled map[string]string `json:"not_canceled"`
+}
+
 // OrderMarketCancelParams represents order market cancel parameters
 type OrderMarketCancelParams struct {
 	Market  *string `json:"market,omitempty"`
@@ -289,6 +311,10 @@ type OrderMarketCancelParams struct {
 type OrderSummary struct {
 	Price string `json:"price"`
 	Size  string `json:"size"`
+
+	// Set when decoded with DecodeOptions.ParseNumbers
+	PriceValue float64 `json:"-"`
+	SizeValue  float64 `json:"-"`
 }
 
 // OrderBookSummary represents order book summary
@@ -16,6 +16,11 @@ const (
 	EventTypePriceChange    EventType = "price_change"
 	EventTypeTickSizeChange EventType = "tick_size_change"
 	EventTypeLastTradePrice EventType = "last_trade_price"
+
+	// Sent only to subscriptions with custom_feature_enabled
+	EventTypeBestBidAsk     EventType = "best_bid_ask"
+	EventTypeNewMarket      EventType = "new_market"
+	EventTypeMarketResolved EventType = "market_resolved"
 )
 
 // Note: OrderSummary and Side types are already defined in types.go
@@ -60,6 +65,10 @@ type PriceChange struct {
 	Hash    string `json:"hash"`
 	BestBid string `json:"best_bid"`
 	BestAsk string `json:"best_ask"`
+
+	// Set when decoded with DecodeOptions.ParseNumbers
+	PriceValue float64 `json:"-"`
+	SizeValue  float64 `json:"-"`
 }
 
 // Validate validates the PriceChange
@@ -155,6 +164,10 @@ type LastTradePriceMessage struct {
 	Size       string    `json:"size"`
 	FeeRateBps string    `json:"fee_rate_bps"`
 	Timestamp  string    `json:"timestamp"`
+
+	// Set when decoded with DecodeOptions.ParseNumbers
+	PriceValue float64 `json:"-"`
+	SizeValue  float64 `json:"-"`
 }
 
 // Validate validates the LastTradePriceMessage
@@ -183,6 +196,110 @@ func (m *LastTradePriceMessage) Validate() error {
 	return nil
 }
 
+// BestBidAskMessage reports a change of an asset's best bid or ask
+type BestBidAskMessage struct {
+	EventType EventType `json:"event_type"`
+	AssetID   string    `json:"asset_id"`
+	Market    string    `json:"market"`
+	BestBid   string    `json:"best_bid"`
+	BestAsk   string    `json:"best_ask"`
+	Spread    string    `json:"spread"`
+	Timestamp string    `json:"timestamp"`
+}
+
+// Validate validates the BestBidAskMessage
+func (m *BestBidAskMessage) Validate() error {
+	if m.EventType != EventTypeBestBidAsk {
+		return fmt.Errorf("invalid event_type: expected 'best_bid_ask', got '%s'", m.EventType)
+	}
+	if m.AssetID == "" {
+		return fmt.Errorf("asset_id is required")
+	}
+	if m.Market == "" {
+		return fmt.Errorf("market is required")
+	}
+	if m.Timestamp == "" {
+		return fmt.Errorf("timestamp is required")
+	}
+	return nil
+}
+
+// MarketEventSummary is the event a new or resolved market belongs to
+type MarketEventSummary struct {
+	ID          string `json:"id"`
+	Ticker      string `json:"ticker"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// NewMarketMessage announces a newly created market
+type NewMarketMessage struct {
+	EventType    EventType           `json:"event_type"`
+	ID           string              `json:"id"`
+	Question     string              `json:"question"`
+	Market       string              `json:"market"`
+	Slug         string              `json:"slug"`
+	Description  string              `json:"description"`
+	AssetIDs     []string            `json:"assets_ids"`
+	Outcomes     []string            `json:"outcomes"`
+	EventMessage *MarketEventSummary `json:"event_message,omitempty"`
+	Timestamp    string              `json:"timestamp"`
+}
+
+// Validate validates the NewMarketMessage
+func (m *NewMarketMessage) Validate() error {
+	if m.EventType != EventTypeNewMarket {
+		return fmt.Errorf("invalid event_type: expected 'new_market', got '%s'", m.EventType)
+	}
+	if m.Market == "" {
+		return fmt.Errorf("market is required")
+	}
+	return nil
+}
+
+// MarketResolvedMessage announces the resolution of a market
+type MarketResolvedMessage struct {
+	EventType      EventType           `json:"event_type"`
+	ID             string              `json:"id"`
+	Question       string              `json:"question"`
+	Market         string              `json:"market"`
+	Slug           string              `json:"slug"`
+	Description    string              `json:"description"`
+	AssetIDs       []string            `json:"assets_ids"`
+	Outcomes       []string            `json:"outcomes"`
+	WinningAssetID string              `json:"winning_asset_id"`
+	WinningOutcome string              `json:"winning_outcome"`
+	EventMessage   *MarketEventSummary `json:"event_message,omitempty"`
+	Timestamp      string              `json:"timestamp"`
+}
+
+// Validate validates the MarketResolvedMessage
+func (m *MarketResolvedMessage) Validate() error {
+	if m.EventType != EventTypeMarketResolved {
+		return fmt.Errorf("invalid event_type: expected 'market_resolved', got '%s'", m.EventType)
+	}
+	if m.Market == "" {
+		return fmt.Errorf("market is required")
+	}
+	if m.WinningAssetID == "" {
+		return fmt.Errorf("winning_asset_id is required")
+	}
+	return nil
+}
+
+// UnknownMessage is a message with an event_type this package does not know yet. It keeps
+// the raw JSON so newer events can be handled before the SDK supports them.
+type UnknownMessage struct {
+	EventType EventType
+	Raw       json.RawMessage
+}
+
+// Validate accepts any unknown message
+func (m *UnknownMessage) Validate() error {
+	return nil
+}
+
 // MarketChannelMessage is a union type for all market channel messages
 type MarketChannelMessage interface {
 	Validate() error
@@ -209,62 +326,43 @@ func (m *LastTradePriceMessage) GetEventType() EventType {
 	return m.EventType
 }
 
-// ParseMarketChannelMessage parses and validates a WebSocket message
-func ParseMarketChannelMessage(data []byte) (MarketChannelMessage, error) {
-	// First, unmarshal just to get the event_type
-	var eventTypeWrapper struct {
-		EventType EventType `json:"event_type"`
-	}
-
-	if err := json.Unmarshal(data, &eventTypeWrapper); err != nil {
-		return nil, fmt.Errorf("failed to parse event_type: %w", err)
-	}
+// GetEventType returns the event type for BestBidAskMessage
+func (m *BestBidAskMessage) GetEventType() EventType {
+	return m.EventType
+}
 
-	// Based on event_type, unmarshal into the appropriate type
-	switch eventTypeWrapper.EventType {
-	case EventTypeBook:
-		var msg BookMessage
-		if err := json.Unmarshal(data, &msg); err != nil {
-			return nil, fmt.Errorf("failed to parse book message: %w", err)
-		}
-		if err := msg.Validate(); err != nil {
-			return nil, fmt.Errorf("invalid book message: %w", err)
-		}
-		return &msg, nil
+// GetEventType returns the event type for NewMarketMessage
+func (m *NewMarketMessage) GetEventType() EventType {
+	return m.EventType
+}
 
-	case EventTypePriceChange:
-		var msg PriceChangeMessage
-		if err := json.Unmarshal(data, &msg); err != nil {
-			return nil, fmt.Errorf("failed to parse price_change message: %w", err)
-		}
-		if err := msg.Validate(); err != nil {
-			return nil, fmt.Errorf("invalid price_change message: %w", err)
-		}
-		return &msg, nil
+// GetEventType returns the event type for MarketResolvedMessage
+func (m *MarketResolvedMessage) GetEventType() EventType {
+	return m.EventType
+}
 
-	case EventTypeTickSizeChange:
-		var msg TickSizeChangeMessage
-		if err := json.Unmarshal(data, &msg); err != nil {
-			return nil, fmt.Errorf("failed to parse tick_size_change message: %w", err)
-		}
-		if err := msg.Validate(); err != nil {
-			return nil, fmt.Errorf("invalid tick_size_change message: %w", err)
-		}
-		return &msg, nil
+// GetEventType returns the event type for UnknownMessage
+func (m *UnknownMessage) GetEventType() EventType {
+	return m.EventType
+}
 
-	case EventTypeLastTradePrice:
-		var msg LastTradePriceMessage
-		if err := json.Unmarshal(data, &msg); err != nil {
-			return nil, fmt.Errorf("failed to parse last_trade_price message: %w", err)
-		}
-		if err := msg.Validate(); err != nil {
-			return nil, fmt.Errorf("invalid last_trade_price message: %w", err)
-		}
-		return &msg, nil
+// newUnknownMessage copies data, which may be a reused read buffer
+func newUnknownMessage(eventType EventType, data []byte) *UnknownMessage {
+	return &UnknownMessage{EventType: eventType, Raw: append(json.RawMessage{}, data...)}
+}
 
-	default:
-		return nil, fmt.Errorf("unknown event_type: %s", eventTypeWrapper.EventType)
+// ParseMarketChannelMessage parses and validates a WebSocket message. Messages with an
+// unknown event_type are returned as *UnknownMessage, not as an error. Frames holding
+// arrays of messages are decoded with DecodeMarketChannelMessages.
+func ParseMarketChannelMessage(data []byte) (MarketChannelMessage, error) {
+	messages, err := DecodeMarketChannelMessages(data, DecodeOptions{})
+	if err != nil {
+		return nil, err
 	}
+	if len(messages) != 1 {
+		return nil, fmt.Errorf("expected one message, got %d", len(messages))
+	}
+	return messages[0], nil
 }
 
 // Type assertion helpers
@@ -300,3 +398,35 @@ func AsLastTradePriceMessage(msg MarketChannelMessage) (*LastTradePriceMessage,
 	}
 	return nil, false
 }
+
+// AsBestBidAskMessage attempts to cast to BestBidAskMessage
+func AsBestBidAskMessage(msg MarketChannelMessage) (*BestBidAskMessage, bool) {
+	if m, ok := msg.(*BestBidAskMessage); ok {
+		return m, true
+	}
+	return nil, false
+}
+
+// AsNewMarketMessage attempts to cast to NewMarketMessage
+func AsNewMarketMessage(msg MarketChannelMessage) (*NewMarketMessage, bool) {
+	if m, ok := msg.(*NewMarketMessage); ok {
+		return m, true
+	}
+	return nil, false
+}
+
+// AsMarketResolvedMessage attempts to cast to MarketResolvedMessage
+func AsMarketResolvedMessage(msg MarketChannelMessage) (*MarketResolvedMessage, bool) {
+	if m, ok := msg.(*MarketResolvedMessage); ok {
+		return m, true
+	}
+	return nil, false
+}
+
+// AsUnknownMessage attempts to cast to UnknownMessage
+func AsUnknownMessage(msg MarketChannelMessage) (*UnknownMessage, bool) {
+	if m, ok := msg.(*UnknownMessage); ok {
+		return m, true
+	}
+	return nil, false
+}
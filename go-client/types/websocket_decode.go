@@ -0,0 +1,271 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// DecodeOptions tunes DecodeMarketChannelMessages
+type DecodeOptions struct {
+	// SkipValidation trusts the server and skips Validate on every message
+	SkipValidation bool
+
+	// ParseNumbers fills the PriceValue and SizeValue fields of book levels, price changes
+	// and trades, so consumers do not parse the decimal strings again
+	ParseNumbers bool
+}
+
+// marketWire has the fields of every market channel message, so a frame is decoded in one
+// pass whatever its event types
+type marketWire struct {
+	EventType      EventType           `json:"event_type"`
+	ID             string              `json:"id"`
+	AssetID        string              `json:"asset_id"`
+	Market         string              `json:"market"`
+	Timestamp      string              `json:"timestamp"`
+	Hash           string              `json:"hash"`
+	Bids           []OrderSummary      `json:"bids"`
+	Asks           []OrderSummary      `json:"asks"`
+	PriceChanges   []PriceChange       `json:"price_changes"`
+	OldTickSize    string              `json:"old_tick_size"`
+	NewTickSize    string              `json:"new_tick_size"`
+	Price          string              `json:"price"`
+	Side           Side                `json:"side"`
+	Size           string              `json:"size"`
+	FeeRateBps     string              `json:"fee_rate_bps"`
+	BestBid        string              `json:"best_bid"`
+	BestAsk        string              `json:"best_ask"`
+	Spread         string              `json:"spread"`
+	Question       string              `json:"question"`
+	Slug           string              `json:"slug"`
+	Description    string              `json:"description"`
+	AssetIDs       []string            `json:"assets_ids"`
+	Outcomes       []string            `json:"outcomes"`
+	WinningAssetID string              `json:"winning_asset_id"`
+	WinningOutcome string              `json:"winning_outcome"`
+	EventMessage   *MarketEventSummary `json:"event_message"`
+}
+
+// wireBuffer holds decoded wires between frames; the messages built from them take over
+// their slices, so only the backing array is reused
+type wireBuffer struct {
+	wires []marketWire
+}
+
+var wireBuffers = sync.Pool{New: func() any { return new(wireBuffer) }}
+
+func (b *wireBuffer) release() {
+	clear(b.wires[:cap(b.wires)])
+	b.wires = b.wires[:0]
+	wireBuffers.Put(b)
+}
+
+// DecodeMarketChannelMessages decodes a market channel frame, a single message or an
+// array of them, in one pass. Messages that fail validation or number parsing are left
+// out and reported in the returned error; the others are still returned. Unknown event
+// types become *UnknownMessage. The returned messages do not reference data.
+func DecodeMarketChannelMessages(data []byte, options DecodeOptions) ([]MarketChannelMessage, error) {
+	buf := wireBuffers.Get().(*wireBuffer)
+	defer buf.release()
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &buf.wires); err != nil {
+			return nil, fmt.Errorf("failed to parse message: %w", err)
+		}
+
+		messages := make([]MarketChannelMessage, 0, len(buf.wires))
+		var errs []error
+		var raws []json.RawMessage
+		for i := range buf.wires {
+			msg, err := buf.wires[i].message(options)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("message %d: %w", i, err))
+				continue
+			}
+			if unknown, ok := msg.(*UnknownMessage); ok {
+				// Rare: decode the frame again only to keep the element's raw JSON
+				if raws == nil {
+					json.Unmarshal(data, &raws)
+				}
+				if i < len(raws) {
+					unknown.Raw = raws[i]
+				}
+			}
+			messages = append(messages, msg)
+		}
+		return messages, errors.Join(errs...)
+	}
+
+	buf.wires = append(buf.wires, marketWire{})
+	wire := &buf.wires[0]
+	if err := json.Unmarshal(data, wire); err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+	msg, err := wire.message(options)
+	if err != nil {
+		return nil, err
+	}
+	if unknown, ok := msg.(*UnknownMessage); ok {
+		unknown.Raw = append(json.RawMessage{}, data...)
+	}
+	return []MarketChannelMessage{msg}, nil
+}
+
+// message builds the typed message, taking over the wire's slices
+func (w *marketWire) message(options DecodeOptions) (MarketChannelMessage, error) {
+	var msg MarketChannelMessage
+	switch w.EventType {
+	case EventTypeBook:
+		m := &BookMessage{
+			EventType: w.EventType,
+			AssetID:   w.AssetID,
+			Market:    w.Market,
+			Timestamp: w.Timestamp,
+			Hash:      w.Hash,
+			Bids:      w.Bids,
+			Asks:      w.Asks,
+		}
+		if options.ParseNumbers {
+			if err := parseLevels(m.Bids); err != nil {
+				return nil, fmt.Errorf("invalid book message: %w", err)
+			}
+			if err := parseLevels(m.Asks); err != nil {
+				return nil, fmt.Errorf("invalid book message: %w", err)
+			}
+		}
+		msg = m
+
+	case EventTypePriceChange:
+		m := &PriceChangeMessage{
+			EventType:    w.EventType,
+			Market:       w.Market,
+			PriceChanges: w.PriceChanges,
+			Timestamp:    w.Timestamp,
+		}
+		if options.ParseNumbers {
+			for i := range m.PriceChanges {
+				change := &m.PriceChanges[i]
+				if err := parseNumber(change.Price, &change.PriceValue); err != nil {
+					return nil, fmt.Errorf("invalid price_change message: %w", err)
+				}
+				if err := parseNumber(change.Size, &change.SizeValue); err != nil {
+					return nil, fmt.Errorf("invalid price_change message: %w", err)
+				}
+			}
+		}
+		msg = m
+
+	case EventTypeTickSizeChange:
+		msg = &TickSizeChangeMessage{
+			EventType:   w.EventType,
+			AssetID:     w.AssetID,
+			Market:      w.Market,
+			OldTickSize: w.OldTickSize,
+			NewTickSize: w.NewTickSize,
+			Timestamp:   w.Timestamp,
+		}
+
+	case EventTypeLastTradePrice:
+		m := &LastTradePriceMessage{
+			EventType:  w.EventType,
+			AssetID:    w.AssetID,
+			Market:     w.Market,
+			Price:      w.Price,
+			Side:       w.Side,
+			Size:       w.Size,
+			FeeRateBps: w.FeeRateBps,
+			Timestamp:  w.Timestamp,
+		}
+		if options.ParseNumbers {
+			if err := parseNumber(m.Price, &m.PriceValue); err != nil {
+				return nil, fmt.Errorf("invalid last_trade_price message: %w", err)
+			}
+			if err := parseNumber(m.Size, &m.SizeValue); err != nil {
+				return nil, fmt.Errorf("invalid last_trade_price message: %w", err)
+			}
+		}
+		msg = m
+
+	case EventTypeBestBidAsk:
+		msg = &BestBidAskMessage{
+			EventType: w.EventType,
+			AssetID:   w.AssetID,
+			Market:    w.Market,
+			BestBid:   w.BestBid,
+			BestAsk:   w.BestAsk,
+			Spread:    w.Spread,
+			Timestamp: w.Timestamp,
+		}
+
+	case EventTypeNewMarket:
+		msg = &NewMarketMessage{
+			EventType:    w.EventType,
+			ID:           w.ID,
+			Question:     w.Question,
+			Market:       w.Market,
+			Slug:         w.Slug,
+			Description:  w.Description,
+			AssetIDs:     w.AssetIDs,
+			Outcomes:     w.Outcomes,
+			EventMessage: w.EventMessage,
+			Timestamp:    w.Timestamp,
+		}
+
+	case EventTypeMarketResolved:
+		msg = &MarketResolvedMessage{
+			EventType:      w.EventType,
+			ID:             w.ID,
+			Question:       w.Question,
+			Market:         w.Market,
+			Slug:           w.Slug,
+			Description:    w.Description,
+			AssetIDs:       w.AssetIDs,
+			Outcomes:       w.Outcomes,
+			WinningAssetID: w.WinningAssetID,
+			WinningOutcome: w.WinningOutcome,
+			EventMessage:   w.EventMessage,
+			Timestamp:      w.Timestamp,
+		}
+
+	default:
+		// Raw is filled in by the caller, which has the message's bytes
+		return &UnknownMessage{EventType: w.EventType}, nil
+	}
+
+	if !options.SkipValidation {
+		if err := msg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid %s message: %w", w.EventType, err)
+		}
+	}
+	return msg, nil
+}
+
+func parseLevels(levels []OrderSummary) error {
+	for i := range levels {
+		if err := parseNumber(levels[i].Price, &levels[i].PriceValue); err != nil {
+			return err
+		}
+		if err := parseNumber(levels[i].Size, &levels[i].SizeValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseNumber parses a decimal string; an empty string is left as 0
+func parseNumber(s string, value *float64) error {
+	if s == "" {
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	*value = v
+	return nil
+}
@@ -0,0 +1,168 @@
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+const bookFrame = `{"event_type":"book","asset_id":"1","market":"0xc0","timestamp":"1700000000000","hash":"0xab",` +
+	`"bids":[{"price":"0.48","size":"100"},{"price":"0.47","size":"250.5"}],` +
+	`"asks":[{"price":"0.52","size":"100"}]}`
+
+const priceChangeFrame = `{"event_type":"price_change","market":"0xc0","timestamp":"1700000000001",` +
+	`"price_changes":[{"asset_id":"1","price":"0.49","size":"30","side":"BUY","hash":"0xcd","best_bid":"0.49","best_ask":"0.52"}]}`
+
+const tradeFrame = `{"event_type":"last_trade_price","asset_id":"1","market":"0xc0","price":"0.5","side":"SELL",` +
+	`"size":"12","fee_rate_bps":"0","timestamp":"1700000000002"}`
+
+func TestDecodeMarketChannelMessages(t *testing.T) {
+	frame := "[" + bookFrame + "," + priceChangeFrame + "," + tradeFrame + `,{"event_type":"mystery","x":1}]`
+
+	messages, err := types.DecodeMarketChannelMessages([]byte(frame), types.DecodeOptions{ParseNumbers: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 4 {
+		t.Fatalf("got %d messages, want 4", len(messages))
+	}
+
+	book, ok := types.AsBookMessage(messages[0])
+	if !ok || len(book.Bids) != 2 || book.Bids[1].SizeValue != 250.5 || book.Asks[0].PriceValue != 0.52 {
+		t.Errorf("book = %+v", messages[0])
+	}
+	change, ok := types.AsPriceChangeMessage(messages[1])
+	if !ok || change.PriceChanges[0].PriceValue != 0.49 || change.PriceChanges[0].BestBid != "0.49" {
+		t.Errorf("price change = %+v", messages[1])
+	}
+	trade, ok := types.AsLastTradePriceMessage(messages[2])
+	if !ok || trade.PriceValue != 0.5 || trade.SizeValue != 12 || trade.Side != types.SideSell {
+		t.Errorf("trade = %+v", messages[2])
+	}
+	unknown, ok := messages[3].(*types.UnknownMessage)
+	if !ok || unknown.EventType != "mystery" || string(unknown.Raw) != `{"event_type":"mystery","x":1}` {
+		t.Errorf("unknown = %+v", messages[3])
+	}
+
+	// Pooled wires must not leak fields into the next frame
+	messages, err = types.DecodeMarketChannelMessages([]byte(tradeFrame), types.DecodeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trade, _ := types.AsLastTradePriceMessage(messages[0]); trade.PriceValue != 0 {
+		t.Errorf("PriceValue = %v without ParseNumbers", trade.PriceValue)
+	}
+}
+
+func TestDecodeMarketChannelMessagesPartialFailure(t *testing.T) {
+	invalid := `{"event_type":"book","market":"0xc0","timestamp":"1","hash":"0xab","bids":[],"asks":[]}`
+	frame := "[" + invalid + "," + tradeFrame + "]"
+
+	messages, err := types.DecodeMarketChannelMessages([]byte(frame), types.DecodeOptions{})
+	if err == nil {
+		t.Fatal("expected a validation error for the book without asset_id")
+	}
+	if len(messages) != 1 || messages[0].GetEventType() != types.EventTypeLastTradePrice {
+		t.Errorf("messages = %+v, want the trade only", messages)
+	}
+
+	messages, err = types.DecodeMarketChannelMessages([]byte(frame), types.DecodeOptions{SkipValidation: true})
+	if err != nil || len(messages) != 2 {
+		t.Errorf("SkipValidation: %d messages, err %v", len(messages), err)
+	}
+
+	bad := strings.Replace(tradeFrame, `"price":"0.5"`, `"price":"half"`, 1)
+	if _, err := types.DecodeMarketChannelMessages([]byte(bad), types.DecodeOptions{ParseNumbers: true}); err == nil {
+		t.Error("expected a number parsing error")
+	}
+}
+
+// busyFrame is an array of price changes like those sent for many assets during volatile
+// events
+func busyFrame(n int) []byte {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if i%10 == 0 {
+			b.WriteString(strings.Replace(bookFrame, `"asset_id":"1"`, fmt.Sprintf(`"asset_id":"%d"`, i), 1))
+		} else {
+			b.WriteString(priceChangeFrame)
+		}
+	}
+	b.WriteByte(']')
+	return []byte(b.String())
+}
+
+// legacyDecode is the decoding path before DecodeMarketChannelMessages: the frame as raw
+// elements, then every element twice
+func legacyDecode(data []byte) ([]types.MarketChannelMessage, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		raws = []json.RawMessage{data}
+	}
+	messages := make([]types.MarketChannelMessage, 0, len(raws))
+	for _, raw := range raws {
+		var wrapper struct {
+			EventType types.EventType `json:"event_type"`
+		}
+		if err := json.Unmarshal(raw, &wrapper); err != nil {
+			return nil, err
+		}
+		var msg types.MarketChannelMessage
+		switch wrapper.EventType {
+		case types.EventTypeBook:
+			msg = &types.BookMessage{}
+		case types.EventTypePriceChange:
+			msg = &types.PriceChangeMessage{}
+		default:
+			msg = &types.LastTradePriceMessage{}
+		}
+		if err := json.Unmarshal(raw, msg); err != nil {
+			return nil, err
+		}
+		if err := msg.Validate(); err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+func BenchmarkDecodeMarketChannelMessages(b *testing.B) {
+	for _, n := range []int{1, 100} {
+		frame := busyFrame(n)
+		b.Run(fmt.Sprintf("legacy/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(frame)))
+			for i := 0; i < b.N; i++ {
+				if _, err := legacyDecode(frame); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		for _, options := range []struct {
+			name string
+			types.DecodeOptions
+		}{
+			{"single-pass", types.DecodeOptions{}},
+			{"skip-validation", types.DecodeOptions{SkipValidation: true}},
+			{"parse-numbers", types.DecodeOptions{ParseNumbers: true}},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", options.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(frame)))
+				for i := 0; i < b.N; i++ {
+					if _, err := types.DecodeMarketChannelMessages(frame, options.DecodeOptions); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
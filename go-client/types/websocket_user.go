@@ -0,0 +1,197 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WebSocket User Channel Message Types
+// Based on: https://docs.polymarket.com/developers/CLOB/websocket/user-channel
+
+const (
+	EventTypeOrder EventType = "order"
+	EventTypeTrade EventType = "trade"
+)
+
+// OrderEventType is the kind of change an OrderMessage reports
+type OrderEventType string
+
+const (
+	OrderEventPlacement    OrderEventType = "PLACEMENT"
+	OrderEventUpdate       OrderEventType = "UPDATE"
+	OrderEventCancellation OrderEventType = "CANCELLATION"
+)
+
+// TradeStatus is the settlement status of a trade
+type TradeStatus string
+
+const (
+	// TradeStatusMatched means the trade was matched and sent for settlement
+	TradeStatusMatched TradeStatus = "MATCHED"
+	// TradeStatusMined means the settlement transaction was mined
+	TradeStatusMined TradeStatus = "MINED"
+	// TradeStatusConfirmed means the trade is final
+	TradeStatusConfirmed TradeStatus = "CONFIRMED"
+	// TradeStatusRetrying means settlement failed and is being retried
+	TradeStatusRetrying TradeStatus = "RETRYING"
+	// TradeStatusFailed means settlement failed permanently
+	TradeStatusFailed TradeStatus = "FAILED"
+)
+
+// OrderMessage reports the placement, update (partial match) or cancellation of one
+// of the user's orders
+type OrderMessage struct {
+	EventType       EventType      `json:"event_type"`
+	ID              string         `json:"id"`
+	Type            OrderEventType `json:"type"`
+	Owner           string         `json:"owner"`
+	OrderOwner      string         `json:"order_owner"`
+	Market          string         `json:"market"`
+	AssetID         string         `json:"asset_id"`
+	Side            Side           `json:"side"`
+	Price           string         `json:"price"`
+	OriginalSize    string         `json:"original_size"`
+	SizeMatched     string         `json:"size_matched"`
+	Outcome         string         `json:"outcome"`
+	AssociateTrades []string       `json:"associate_trades"`
+	Timestamp       string         `json:"timestamp"`
+}
+
+// Validate validates the OrderMessage
+func (m *OrderMessage) Validate() error {
+	if m.EventType != EventTypeOrder {
+		return fmt.Errorf("invalid event_type: expected 'order', got '%s'", m.EventType)
+	}
+	if m.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	switch m.Type {
+	case OrderEventPlacement, OrderEventUpdate, OrderEventCancellation:
+	default:
+		return fmt.Errorf("invalid type: must be 'PLACEMENT', 'UPDATE' or 'CANCELLATION', got '%s'", m.Type)
+	}
+	if m.AssetID == "" {
+		return fmt.Errorf("asset_id is required")
+	}
+	if m.Market == "" {
+		return fmt.Errorf("market is required")
+	}
+	return nil
+}
+
+// TradeMessage reports a trade involving one of the user's orders and each change of
+// its settlement status
+type TradeMessage struct {
+	EventType    EventType    `json:"event_type"`
+	ID           string       `json:"id"`
+	Type         string       `json:"type"`
+	Status       TradeStatus  `json:"status"`
+	TakerOrderID string       `json:"taker_order_id"`
+	Market       string       `json:"market"`
+	AssetID      string       `json:"asset_id"`
+	Side         Side         `json:"side"`
+	Size         string       `json:"size"`
+	Price        string       `json:"price"`
+	Outcome      string       `json:"outcome"`
+	Owner        string       `json:"owner"`
+	TradeOwner   string       `json:"trade_owner"`
+	MakerOrders  []MakerOrder `json:"maker_orders"`
+	MatchTime    string       `json:"matchtime"`
+	LastUpdate   string       `json:"last_update"`
+	Timestamp    string       `json:"timestamp"`
+}
+
+// Validate validates the TradeMessage
+func (m *TradeMessage) Validate() error {
+	if m.EventType != EventTypeTrade {
+		return fmt.Errorf("invalid event_type: expected 'trade', got '%s'", m.EventType)
+	}
+	if m.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	if m.Status == "" {
+		return fmt.Errorf("status is required")
+	}
+	if m.AssetID == "" {
+		return fmt.Errorf("asset_id is required")
+	}
+	if m.Market == "" {
+		return fmt.Errorf("market is required")
+	}
+	return nil
+}
+
+// IsFinal reports whether the trade status will not change again
+func (m *TradeMessage) IsFinal() bool {
+	return m.Status == TradeStatusConfirmed || m.Status == TradeStatusFailed
+}
+
+// GetEventType returns the event type for OrderMessage
+func (m *OrderMessage) GetEventType() EventType {
+	return m.EventType
+}
+
+// GetEventType returns the event type for TradeMessage
+func (m *TradeMessage) GetEventType() EventType {
+	return m.EventType
+}
+
+// UserChannelMessage is a union type for all user channel messages. It has the same
+// methods as MarketChannelMessage, so both flow through WebSocketCallbacks.OnMessage.
+type UserChannelMessage interface {
+	Validate() error
+	GetEventType() EventType
+}
+
+// ParseUserChannelMessage parses and validates a user channel WebSocket message. Messages
+// with an unknown event_type are returned as *UnknownMessage.
+func ParseUserChannelMessage(data []byte) (UserChannelMessage, error) {
+	var eventTypeWrapper struct {
+		EventType EventType `json:"event_type"`
+	}
+
+	if err := json.Unmarshal(data, &eventTypeWrapper); err != nil {
+		return nil, fmt.Errorf("failed to parse event_type: %w", err)
+	}
+
+	switch eventTypeWrapper.EventType {
+	case EventTypeOrder:
+		var msg OrderMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, fmt.Errorf("failed to parse order message: %w", err)
+		}
+		if err := msg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid order message: %w", err)
+		}
+		return &msg, nil
+
+	case EventTypeTrade:
+		var msg TradeMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, fmt.Errorf("failed to parse trade message: %w", err)
+		}
+		if err := msg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid trade message: %w", err)
+		}
+		return &msg, nil
+
+	default:
+		return newUnknownMessage(eventTypeWrapper.EventType, data), nil
+	}
+}
+
+// AsOrderMessage attempts to cast to OrderMessage
+func AsOrderMessage(msg UserChannelMessage) (*OrderMessage, bool) {
+	if m, ok := msg.(*OrderMessage); ok {
+		return m, true
+	}
+	return nil, false
+}
+
+// AsTradeMessage attempts to cast to TradeMessage
+func AsTradeMessage(msg UserChannelMessage) (*TradeMessage, bool) {
+	if m, ok := msg.(*TradeMessage); ok {
+		return m, true
+	}
+	return nil, false
+}